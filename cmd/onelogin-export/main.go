@@ -0,0 +1,62 @@
+// onelogin-export generates terraform configuration with import blocks
+// for the resources in an existing OneLogin account.
+//
+// Credentials are read from the ONELOGIN_CLIENT_ID, ONELOGIN_CLIENT_SECRET
+// and ONELOGIN_SUBDOMAIN environment variables.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghaggin/terraform-provider-onelogin/internal/provider"
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+)
+
+func main() {
+	var out, resources string
+
+	flag.StringVar(&out, "out", ".", "directory to write the generated .tf files to")
+	flag.StringVar(&resources, "resources", strings.Join(provider.ExportResourceTypes, ","), "comma separated list of resource types to export")
+	flag.Parse()
+
+	config := &onelogin.ClientConfig{
+		ClientID:     os.Getenv("ONELOGIN_CLIENT_ID"),
+		ClientSecret: os.Getenv("ONELOGIN_CLIENT_SECRET"),
+		Subdomain:    os.Getenv("ONELOGIN_SUBDOMAIN"),
+		Timeout:      60 * time.Second,
+		ReadCache:    true,
+	}
+	if config.ClientID == "" || config.ClientSecret == "" || config.Subdomain == "" {
+		log.Fatal("ONELOGIN_CLIENT_ID, ONELOGIN_CLIENT_SECRET and ONELOGIN_SUBDOMAIN must be set")
+	}
+
+	client, err := onelogin.NewClient(config)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	files, err := provider.Export(context.Background(), client, strings.Split(resources, ","))
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = os.MkdirAll(out, 0o755)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	for name, content := range files {
+		path := filepath.Join(out, name)
+		err = os.WriteFile(path, content, 0o644)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		log.Printf("wrote %s", path)
+	}
+}
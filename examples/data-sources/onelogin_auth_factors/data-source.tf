@@ -0,0 +1,7 @@
+data "onelogin_auth_factors" "example" {
+  user_id = 123456
+}
+
+output "factor_ids" {
+  value = { for f in data.onelogin_auth_factors.example.factors : f.name => f.factor_id }
+}
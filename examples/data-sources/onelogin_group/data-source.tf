@@ -0,0 +1,7 @@
+data "onelogin_group" "engineering" {
+  name = "Engineering"
+}
+
+output "engineering_group_id" {
+  value = data.onelogin_group.engineering.id
+}
@@ -0,0 +1,5 @@
+data "onelogin_groups" "all" {}
+
+output "group_ids" {
+  value = { for g in data.onelogin_groups.all.groups : g.name => g.id }
+}
@@ -0,0 +1,5 @@
+data "onelogin_mapping_order" "current" {}
+
+output "enabled_mapping_ids" {
+  value = [for m in data.onelogin_mapping_order.current.enabled : m.id]
+}
@@ -0,0 +1,7 @@
+data "onelogin_policies" "user" {
+  type = "user"
+}
+
+output "user_policy_ids" {
+  value = { for p in data.onelogin_policies.user.policies : p.name => p.id }
+}
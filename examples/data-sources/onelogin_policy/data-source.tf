@@ -0,0 +1,10 @@
+data "onelogin_policy" "contractors" {
+  name = "Contractors"
+  type = "app"
+}
+
+resource "onelogin_app" "example" {
+  name         = "Example"
+  connector_id = 108419
+  policy_id    = data.onelogin_policy.contractors.id
+}
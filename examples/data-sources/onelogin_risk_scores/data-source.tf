@@ -0,0 +1,7 @@
+data "onelogin_risk_scores" "last_week" {
+  after = formatdate("YYYY-MM-DD", timeadd(plantimestamp(), "-168h"))
+}
+
+output "high_risk_logins" {
+  value = data.onelogin_risk_scores.last_week.high + data.onelogin_risk_scores.last_week.very_high
+}
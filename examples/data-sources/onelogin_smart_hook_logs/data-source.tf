@@ -0,0 +1,7 @@
+data "onelogin_smart_hook_logs" "pre_auth" {
+  hook_id = onelogin_smart_hook.pre_auth.id
+}
+
+output "last_events" {
+  value = try(data.onelogin_smart_hook_logs.pre_auth.logs[0].events, [])
+}
@@ -0,0 +1,7 @@
+data "onelogin_user_mfa_devices" "admin" {
+  user_id = 123456
+}
+
+output "admin_enrolled_in_mfa" {
+  value = length(data.onelogin_user_mfa_devices.admin.devices) > 0
+}
@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_api_authorization.example 123456
+
+# Import by name, fails if more than one api authorization has this name
+terraform import onelogin_api_authorization.example "name:Internal API"
@@ -0,0 +1,23 @@
+resource "onelogin_api_authorization" "internal_api" {
+  name                = "Internal API"
+  description         = "Access tokens for internal services"
+  resource_identifier = "https://api.example.com"
+}
+
+resource "onelogin_api_authorization_scope" "read_users" {
+  api_authorization_id = onelogin_api_authorization.internal_api.id
+  value                = "read:users"
+  description          = "Read users"
+}
+
+resource "onelogin_api_authorization_claim" "email" {
+  api_authorization_id    = onelogin_api_authorization.internal_api.id
+  name                    = "email"
+  user_attribute_mappings = "email"
+}
+
+resource "onelogin_api_authorization_client" "service" {
+  api_authorization_id = onelogin_api_authorization.internal_api.id
+  app_id               = onelogin_app.service.id
+  scopes               = [onelogin_api_authorization_scope.read_users.id]
+}
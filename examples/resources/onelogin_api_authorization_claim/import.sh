@@ -0,0 +1,2 @@
+# Import with <api_authorization_id>/<claim_id>
+terraform import onelogin_api_authorization_claim.example 123456/789
@@ -0,0 +1,2 @@
+# Import with <api_authorization_id>/<app_id>
+terraform import onelogin_api_authorization_client.example 123456/789
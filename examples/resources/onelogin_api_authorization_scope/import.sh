@@ -0,0 +1,2 @@
+# Import with <api_authorization_id>/<scope_id>
+terraform import onelogin_api_authorization_scope.example 123456/789
@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_app.example 123456
+
+# Import by name, fails if more than one app has this name
+terraform import onelogin_app.example "name:Example App"
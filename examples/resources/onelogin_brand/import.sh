@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_brand.example 123456
+
+# Import by name, fails if more than one brand has this name
+terraform import onelogin_brand.example name:Partners
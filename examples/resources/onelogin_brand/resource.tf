@@ -0,0 +1,17 @@
+resource "onelogin_brand" "partners" {
+  name                   = "Partners"
+  enabled                = true
+  custom_color           = "#3C5A99"
+  custom_accent_color    = "#FF9900"
+  custom_support_enabled = true
+  login_instruction      = "Sign in with your partner account"
+
+  logo_file         = "${path.module}/partners-logo.png"
+  background_base64 = filebase64("${path.module}/partners-background.jpg")
+}
+
+resource "onelogin_app" "partner_portal" {
+  name         = "Partner Portal"
+  connector_id = 108419
+  brand_id     = onelogin_brand.partners.id
+}
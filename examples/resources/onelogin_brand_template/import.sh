@@ -0,0 +1,2 @@
+# Import with <brand_id>/<template_id>
+terraform import onelogin_brand_template.example 123456/789
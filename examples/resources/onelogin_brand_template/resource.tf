@@ -0,0 +1,15 @@
+resource "onelogin_brand_template" "forgot_password" {
+  brand_id = onelogin_brand.partners.id
+  type     = "email_forgot_password"
+  locale   = "en"
+  subject  = "Reset your partner account password"
+  html     = file("${path.module}/forgot_password.html")
+  plain    = file("${path.module}/forgot_password.txt")
+}
+
+resource "onelogin_brand_template" "otp" {
+  brand_id = onelogin_brand.partners.id
+  type     = "sms_otp"
+  locale   = "en"
+  message  = "Your partner portal code is {{otp_code}}"
+}
@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_mapping.example 123456
+
+# Import by name, fails if more than one mapping has this name
+terraform import onelogin_mapping.example "name:Example Mapping"
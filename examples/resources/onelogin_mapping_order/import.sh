@@ -0,0 +1,2 @@
+# The mapping order is a singleton and is always imported with the ID "current"
+terraform import onelogin_mapping_order.example current
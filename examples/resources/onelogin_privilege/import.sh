@@ -0,0 +1 @@
+terraform import onelogin_privilege.example 5b9f4e6d-1a2b-4c3d-9e8f-7a6b5c4d3e2f
@@ -0,0 +1,11 @@
+resource "onelogin_privilege" "helpdesk" {
+  name        = "Helpdesk"
+  description = "Unlock users and reset passwords"
+
+  statements = [
+    {
+      actions = ["users:List", "users:Get", "users:Unlock", "users:ResetPassword"]
+      scopes  = ["*"]
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# Import with the privilege id
+terraform import onelogin_privilege_roles.example 5b9f4e6d-1a2b-4c3d-9e8f-7a6b5c4d3e2f
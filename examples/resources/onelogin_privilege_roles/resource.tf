@@ -0,0 +1,4 @@
+resource "onelogin_privilege_roles" "helpdesk" {
+  privilege_id = onelogin_privilege.helpdesk.id
+  role_ids     = [onelogin_role.helpdesk.id]
+}
@@ -0,0 +1,4 @@
+resource "onelogin_privilege_users" "helpdesk" {
+  privilege_id = onelogin_privilege.helpdesk.id
+  user_ids     = [onelogin_user.oncall.id]
+}
@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_risk_rule.example 3e2d4c1b-5a6f-4b7e-8c9d-0a1b2c3d4e5f
+
+# Import by name, fails if more than one risk rule has this name
+terraform import onelogin_risk_rule.example "name:Office IPs"
@@ -0,0 +1,14 @@
+resource "onelogin_risk_rule" "office_ips" {
+  name    = "Office IPs"
+  type    = "whitelist"
+  target  = "location.ip"
+  filters = ["203.0.113.0/24", "198.51.100.7"]
+}
+
+resource "onelogin_risk_rule" "blocked_countries" {
+  name        = "Blocked countries"
+  description = "Deny logins from countries we do not operate in"
+  type        = "blacklist"
+  target      = "location.address.country_iso_code"
+  filters     = ["KP", "IR"]
+}
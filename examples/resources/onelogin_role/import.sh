@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_role.example 123456
+
+# Import by name, fails if more than one role has this name
+terraform import onelogin_role.example "name:Example Role"
@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_self_registration_profile.example 123456
+
+# Import by name, fails if more than one profile has this name
+terraform import onelogin_self_registration_profile.example name:Partners
@@ -0,0 +1,18 @@
+resource "onelogin_role" "partners" {
+  name = "Partners"
+}
+
+resource "onelogin_self_registration_profile" "partners" {
+  name             = "Partners"
+  url              = "partners"
+  enabled          = true
+  title            = "Partner registration"
+  thankyou_message = "Your account will be activated once approved"
+  moderated        = true
+  default_role_id  = onelogin_role.partners.id
+  allowed_domains  = ["partner.example.com", "contractor.example.com"]
+
+  fields = [
+    { custom_attribute_id = 123456 },
+  ]
+}
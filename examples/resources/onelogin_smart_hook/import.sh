@@ -0,0 +1 @@
+terraform import onelogin_smart_hook.example 0b8bbfa6-d2b3-4c5c-a5b4-2b4ba6cd1d2f
@@ -0,0 +1,31 @@
+resource "onelogin_smart_hook_env_var" "api_key" {
+  name  = "API_KEY"
+  value = var.api_key
+}
+
+resource "onelogin_smart_hook" "pre_auth" {
+  type     = "pre-authentication"
+  function = file("${path.module}/pre_auth.js")
+  runtime  = "nodejs18.x"
+  timeout  = 2
+  retries  = 0
+
+  packages = {
+    axios = "1.6.0"
+  }
+
+  env_vars = [onelogin_smart_hook_env_var.api_key.name]
+
+  options = {
+    risk_enabled     = true
+    location_enabled = true
+  }
+
+  conditions = [
+    {
+      source   = "roles"
+      operator = "~"
+      value    = "123456"
+    },
+  ]
+}
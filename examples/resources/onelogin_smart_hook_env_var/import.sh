@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_smart_hook_env_var.example 5d0b5a57-2f09-4c1a-8b6c-9b0e3e1d6a4b
+
+# Import by name, the value is set on the next apply
+terraform import onelogin_smart_hook_env_var.example name:API_KEY
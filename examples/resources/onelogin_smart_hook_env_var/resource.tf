@@ -0,0 +1,4 @@
+resource "onelogin_smart_hook_env_var" "api_key" {
+  name  = "API_KEY"
+  value = var.api_key
+}
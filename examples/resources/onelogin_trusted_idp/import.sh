@@ -0,0 +1,5 @@
+# Import by id
+terraform import onelogin_trusted_idp.example 123456
+
+# Import by name, fails if more than one trusted idp has this name
+terraform import onelogin_trusted_idp.example "name:Partner IdP"
@@ -0,0 +1,10 @@
+resource "onelogin_trusted_idp" "partner" {
+  name                   = "Partner IdP"
+  enabled                = true
+  issuer                 = "https://idp.partner.example.com/saml"
+  sso_endpoint           = "https://idp.partner.example.com/saml/sso"
+  certificate            = file("${path.module}/partner-idp.pem")
+  user_attribute_mapping = "email"
+  login_hint             = true
+  jit_provisioning       = true
+}
@@ -0,0 +1,8 @@
+# Import by id
+terraform import onelogin_user.example 123456
+
+# Import by username
+terraform import onelogin_user.example "username:jdoe"
+
+# Import by email, fails if more than one user has this email
+terraform import onelogin_user.example "email:jdoe@example.com"
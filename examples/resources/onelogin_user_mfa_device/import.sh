@@ -0,0 +1,2 @@
+# Import with <user_id>/<device_id>
+terraform import onelogin_user_mfa_device.example 123456/789
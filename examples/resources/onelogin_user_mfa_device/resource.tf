@@ -0,0 +1,15 @@
+resource "onelogin_user" "service_account" {
+  username = "svc-deploy"
+  email    = "svc-deploy@example.com"
+}
+
+data "onelogin_auth_factors" "service_account" {
+  user_id = onelogin_user.service_account.id
+}
+
+resource "onelogin_user_mfa_device" "email" {
+  user_id      = onelogin_user.service_account.id
+  factor_id    = one([for f in data.onelogin_auth_factors.service_account.factors : f.factor_id if f.auth_factor_name == "Email"])
+  display_name = "Service account email"
+  verified     = true
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+)
+
+// ONELOGIN_CASSETTE runs the suite against cassettes instead of the
+// tenant only.  "record" runs live and records a cassette per test,
+// "replay" runs offline from the recorded cassettes.
+const cassetteEnv = "ONELOGIN_CASSETTE"
+
+const cassetteDir = "testdata/cassettes"
+
+// testTransport is the transport of the suite client and of the providers
+// of acceptance tests.  It sends requests through the cassette of the
+// current test.
+var testTransport = &cassetteSwitch{}
+
+type cassetteSwitch struct {
+	mu      sync.Mutex
+	current *onelogin.CassetteTransport
+}
+
+func (s *cassetteSwitch) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	current := s.current
+	s.mu.Unlock()
+
+	if current == nil {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	return current.RoundTrip(req)
+}
+
+// use sends the requests of the test through a cassette until the
+// returned func is called, which saves recorded cassettes.  Nothing is
+// recorded or replayed if ONELOGIN_CASSETTE is not set.
+func (s *cassetteSwitch) use(name string) (func() error, error) {
+	mode := cassetteMode()
+	if mode == "" {
+		return func() error { return nil }, nil
+	}
+
+	cassette, err := onelogin.NewCassetteTransport(cassettePath(name), mode, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.current = cassette
+	s.mu.Unlock()
+
+	return func() error {
+		s.mu.Lock()
+		s.current = nil
+		s.mu.Unlock()
+		return cassette.Save()
+	}, nil
+}
+
+func cassettePath(name string) string {
+	return filepath.Join(cassetteDir, strings.ReplaceAll(name, "/", "_")+".json")
+}
+
+func cassetteMode() onelogin.CassetteMode {
+	return onelogin.CassetteMode(os.Getenv(cassetteEnv))
+}
+
+// newTestRand returns the source of the random names of a test.  Names are
+// derived from the test name when cassettes are used so that the requests
+// of a replay match the recording.
+func newTestRand(name string) *rand.Rand {
+	if cassetteMode() == "" {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addConsistencyError adds the error of a write that is not visible in
+// reads of the object in time.  Callers still set the state so that the
+// object is tracked.
+func addConsistencyError(d *diag.Diagnostics, kind string, id int64, err error) {
+	if !errors.Is(err, onelogin.ErrNotConsistent) {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read %s %v, got error: %s", kind, id, err),
+		)
+		return
+	}
+
+	d.AddError(
+		fmt.Sprintf("Error waiting for %s", kind),
+		fmt.Sprintf("The %[1]s %[2]v was written but reads of the %[1]s did not reflect the write: %[3]s. "+
+			"Refresh the state once OneLogin is consistent.", kind, id, err),
+	)
+}
+
+// sameIDs reports whether a and b contain the same ids in any order
+func sameIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return containsIDs(a, b)
+}
+
+// containsIDs reports whether all ids are in set
+func containsIDs(set, ids []int64) bool {
+	m := make(map[int64]bool, len(set))
+	for _, id := range set {
+		m[id] = true
+	}
+	for _, id := range ids {
+		if !m[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalPointers reports whether both pointers are nil or point to equal values
+func equalPointers[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,477 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportResourceTypes are the resource types supported by Export,
+// in the order they are exported.
+var ExportResourceTypes = []string{
+	"onelogin_app",
+	"onelogin_role",
+	"onelogin_mapping",
+	"onelogin_mapping_order",
+	"onelogin_user",
+}
+
+// exportedResource is a single resource read from OneLogin
+// along with the address and id used to import it.
+type exportedResource struct {
+	label    string
+	importID string
+	state    tfsdk.State
+}
+
+type exportFunc func(ctx context.Context, client *onelogin.Client, s schema.Schema) ([]exportedResource, diag.Diagnostics)
+
+type exporter struct {
+	newResource func(client *onelogin.Client) newResourceFunc
+	export      exportFunc
+}
+
+var exporters = map[string]exporter{
+	"onelogin_app":           {NewOneLoginAppResource, exportApps},
+	"onelogin_role":          {NewOneLoginRoleResource, exportRoles},
+	"onelogin_mapping":       {NewOneLoginMappingResource, exportMappings},
+	"onelogin_mapping_order": {NewOneLoginMappingOrderResource, exportMappingOrder},
+	"onelogin_user":          {NewOneLoginUserResource, exportUsers},
+}
+
+// Export reads the resources in a OneLogin account and generates terraform
+// configuration with an import block for each resource.  The state of each
+// resource is built with the same functions used by the resources so that
+// planning the generated configuration produces an empty plan.
+//
+// The result maps file names, one per resource type, to file contents.
+// All resource types in ExportResourceTypes are exported if resourceTypes is empty.
+func Export(ctx context.Context, client *onelogin.Client, resourceTypes []string) (map[string][]byte, error) {
+	if len(resourceTypes) == 0 {
+		resourceTypes = ExportResourceTypes
+	}
+
+	files := map[string][]byte{}
+	for _, resourceType := range resourceTypes {
+		e, ok := exporters[resourceType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported resource type %q, expected one of %s", resourceType, strings.Join(ExportResourceTypes, ", "))
+		}
+
+		schemaResp := resource.SchemaResponse{}
+		e.newResource(client)().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			return nil, diagsError(schemaResp.Diagnostics)
+		}
+
+		resources, diags := e.export(ctx, client, schemaResp.Schema)
+		if diags.HasError() {
+			return nil, fmt.Errorf("export %s: %w", resourceType, diagsError(diags))
+		}
+
+		content, err := exportFile(resourceType, schemaResp.Schema, resources)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", resourceType, err)
+		}
+		files[resourceType+".tf"] = content
+	}
+
+	return files, nil
+}
+
+func exportFile(resourceType string, s schema.Schema, resources []exportedResource) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	taken := map[string]bool{}
+	for _, r := range resources {
+		label := exportLabel(r.label, r.importID, taken)
+
+		importBody := body.AppendNewBlock("import", nil).Body()
+		importBody.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: resourceType},
+			hcl.TraverseAttr{Name: label},
+		})
+		importBody.SetAttributeValue("id", cty.StringVal(r.importID))
+		body.AppendNewline()
+
+		values, err := configValues(s.Attributes, r.state.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", resourceType, label, err)
+		}
+
+		resourceBody := body.AppendNewBlock("resource", []string{resourceType, label}).Body()
+		for _, name := range sortedKeys(values) {
+			resourceBody.SetAttributeValue(name, values[name])
+		}
+		body.AppendNewline()
+	}
+
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+// configValues converts the configurable attributes of an object to cty values.
+// Computed only attributes and null values are omitted from the result.
+func configValues(attributes map[string]schema.Attribute, val tftypes.Value) (map[string]cty.Value, error) {
+	fields := map[string]tftypes.Value{}
+	if err := val.As(&fields); err != nil {
+		return nil, err
+	}
+
+	values := map[string]cty.Value{}
+	for name, attribute := range attributes {
+		if !attribute.IsRequired() && !attribute.IsOptional() {
+			continue
+		}
+
+		v, ok := fields[name]
+		if !ok || v.IsNull() {
+			continue
+		}
+
+		converted, err := attributeToCty(attribute, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		values[name] = converted
+	}
+
+	return values, nil
+}
+
+func attributeToCty(attribute schema.Attribute, val tftypes.Value) (cty.Value, error) {
+	switch a := attribute.(type) {
+	case schema.SingleNestedAttribute:
+		return nestedObjectToCty(a.Attributes, val)
+	case schema.ListNestedAttribute:
+		return nestedListToCty(a.NestedObject.Attributes, val)
+	case schema.SetNestedAttribute:
+		return nestedListToCty(a.NestedObject.Attributes, val)
+	case schema.MapNestedAttribute:
+		elems := map[string]tftypes.Value{}
+		if err := val.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		values := map[string]cty.Value{}
+		for k, elem := range elems {
+			v, err := nestedObjectToCty(a.NestedObject.Attributes, elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values[k] = v
+		}
+		return cty.ObjectVal(values), nil
+	default:
+		return valueToCty(val)
+	}
+}
+
+func nestedObjectToCty(attributes map[string]schema.Attribute, val tftypes.Value) (cty.Value, error) {
+	values, err := configValues(attributes, val)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return cty.ObjectVal(values), nil
+}
+
+func nestedListToCty(attributes map[string]schema.Attribute, val tftypes.Value) (cty.Value, error) {
+	elems := []tftypes.Value{}
+	if err := val.As(&elems); err != nil {
+		return cty.NilVal, err
+	}
+	values := make([]cty.Value, len(elems))
+	for i, elem := range elems {
+		v, err := nestedObjectToCty(attributes, elem)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		values[i] = v
+	}
+	return cty.TupleVal(values), nil
+}
+
+// valueToCty converts a value without schema information.  Collections are
+// converted to tuples and objects so elements do not need to share a type.
+func valueToCty(val tftypes.Value) (cty.Value, error) {
+	if val.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	if !val.IsKnown() {
+		return cty.NilVal, fmt.Errorf("unknown value")
+	}
+
+	typ := val.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := val.As(&s)
+		return cty.StringVal(s), err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		err := val.As(&n)
+		return cty.NumberVal(n), err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := val.As(&b)
+		return cty.BoolVal(b), err
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		elems := []tftypes.Value{}
+		if err := val.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		values := make([]cty.Value, len(elems))
+		for i, elem := range elems {
+			v, err := valueToCty(elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values[i] = v
+		}
+		return cty.TupleVal(values), nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		elems := map[string]tftypes.Value{}
+		if err := val.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		values := map[string]cty.Value{}
+		for k, elem := range elems {
+			v, err := valueToCty(elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values[k] = v
+		}
+		return cty.ObjectVal(values), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported type %s", typ)
+	}
+}
+
+var nonLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportLabel converts a name into a unique resource label.  Names that
+// collide with an existing label are suffixed with the import id.
+func exportLabel(name, importID string, taken map[string]bool) string {
+	label := strings.Trim(nonLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		label = "unnamed"
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+	if taken[label] {
+		label = label + "_" + nonLabelChars.ReplaceAllString(importID, "_")
+	}
+	taken[label] = true
+	return label
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func diagsError(diags diag.Diagnostics) error {
+	msgs := []string{}
+	for _, d := range diags.Errors() {
+		msgs = append(msgs, d.Summary()+": "+d.Detail())
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "; "))
+}
+
+func newExportState(ctx context.Context, s schema.Schema) tfsdk.State {
+	return tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+}
+
+// setExportState builds an exported resource from a resource model.
+func setExportState(ctx context.Context, s schema.Schema, label string, id int64, model any) (exportedResource, diag.Diagnostics) {
+	state := newExportState(ctx, s)
+	diags := state.Set(ctx, model)
+	return exportedResource{
+		label:    label,
+		importID: strconv.FormatInt(id, 10),
+		state:    state,
+	}, diags
+}
+
+// List requests made during export retry on 429 and 502 errors.
+const (
+	exportListRetry     = 3
+	exportListRetryWait = time.Second
+)
+
+func exportApps(ctx context.Context, client *onelogin.Client, s schema.Schema) ([]exportedResource, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	apps, err := onelogin.ListAll[onelogin.Application](client, &onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathApps,
+		Retry:     exportListRetry,
+		RetryWait: exportListRetryWait,
+	})
+	if err != nil {
+		diags.AddError("client error", fmt.Sprintf("Unable to list apps, got error: %s", err))
+		return nil, diags
+	}
+
+	// The list endpoint does not include the full app, read each
+	// app the same way the resource does.
+	r := &oneloginAppResource{client: client}
+	resources := []exportedResource{}
+	for _, app := range apps {
+		app.UnescapeFields()
+		state := newExportState(ctx, s)
+		r.read(ctx, &oneloginApp{ID: types.Int64Value(app.ID), Timeouts: timeoutsNull()}, &state, &diags)
+		if diags.HasError() {
+			return nil, diags
+		}
+		resources = append(resources, exportedResource{
+			label:    app.Name,
+			importID: strconv.FormatInt(app.ID, 10),
+			state:    state,
+		})
+	}
+
+	return resources, diags
+}
+
+// exportRoles exports roles without users.  Role membership is usually
+// managed by mappings and tracking every user would make the role
+// resource take ownership of all of them.
+func exportRoles(ctx context.Context, client *onelogin.Client, s schema.Schema) ([]exportedResource, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	roles, err := onelogin.ListAll[onelogin.Role](client, &onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathRoles,
+		Retry:     exportListRetry,
+		RetryWait: exportListRetryWait,
+	})
+	if err != nil {
+		diags.AddError("client error", fmt.Sprintf("Unable to list roles, got error: %s", err))
+		return nil, diags
+	}
+
+	r := NewOneLoginRoleResource(client)().(*oneloginRoleResource)
+	resources := []exportedResource{}
+	for _, role := range roles {
+		state, newDiags := r.read(ctx, role.ID, types.SetNull(types.Int64Type))
+		diags.Append(newDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		exported, newDiags := setExportState(ctx, s, role.Name, role.ID, state)
+		diags.Append(newDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		resources = append(resources, exported)
+	}
+
+	return resources, diags
+}
+
+func exportMappings(ctx context.Context, client *onelogin.Client, s schema.Schema) ([]exportedResource, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	mappings, err := listMappings(ctx, client, nil, nil)
+	if err != nil {
+		diags.AddError("client error", fmt.Sprintf("Unable to list mappings, got error: %s", err))
+		return nil, diags
+	}
+
+	resources := []exportedResource{}
+	for i := range mappings {
+		state, newDiags := mappingToState(ctx, &mappings[i])
+		diags.Append(newDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		exported, newDiags := setExportState(ctx, s, mappings[i].Name, mappings[i].ID, state)
+		diags.Append(newDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		resources = append(resources, exported)
+	}
+
+	return resources, diags
+}
+
+func exportMappingOrder(ctx context.Context, client *onelogin.Client, s schema.Schema) ([]exportedResource, diag.Diagnostics) {
+	order, diags := currentMappingOrder(ctx, client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	state := newExportState(ctx, s)
+	diags.Append(state.Set(ctx, order)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return []exportedResource{{
+		label:    "this",
+		importID: mappingOrderImportID,
+		state:    state,
+	}}, diags
+}
+
+func exportUsers(ctx context.Context, client *onelogin.Client, s schema.Schema) ([]exportedResource, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	users, err := onelogin.ListAll[onelogin.User](client, &onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathUsers,
+		Retry:     exportListRetry,
+		RetryWait: exportListRetryWait,
+	})
+	if err != nil {
+		diags.AddError("client error", fmt.Sprintf("Unable to list users, got error: %s", err))
+		return nil, diags
+	}
+
+	resources := []exportedResource{}
+	for _, user := range users {
+		exported, newDiags := setExportState(ctx, s, user.Username, user.ID, &oneloginUserModel{
+			ID:          types.Int64Value(user.ID),
+			Username:    types.StringValue(user.Username),
+			LastUpdated: types.StringNull(),
+		})
+		diags.Append(newDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		resources = append(resources, exported)
+	}
+
+	return resources, diags
+}
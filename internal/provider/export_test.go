@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFile(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	NewOneLoginMappingResource(nil)().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	resources := []exportedResource{}
+	for _, m := range []*onelogin.Mapping{
+		{
+			ID:    1,
+			Name:  "Engineering Role",
+			Match: "all",
+			Conditions: []onelogin.MappingCondition{
+				{Source: "member_of", Operator: "contains", Value: "engineering"},
+			},
+			Actions: []onelogin.MappingAction{
+				{Action: "add_role", Value: []string{"123"}},
+			},
+		},
+		{
+			ID:    2,
+			Name:  "engineering role",
+			Match: "any",
+			Conditions: []onelogin.MappingCondition{
+				{Source: "has_role", Operator: "ri", Value: "456"},
+			},
+			Actions: []onelogin.MappingAction{
+				{Action: "set_status", Value: []string{"2"}},
+			},
+		},
+	} {
+		state, diags := mappingToState(ctx, m)
+		require.False(t, diags.HasError(), diags.Errors())
+		exported, diags := setExportState(ctx, schemaResp.Schema, m.Name, m.ID, state)
+		require.False(t, diags.HasError(), diags.Errors())
+		resources = append(resources, exported)
+	}
+
+	content, err := exportFile("onelogin_mapping", schemaResp.Schema, resources)
+	require.NoError(t, err)
+	assert.Equal(t, `import {
+  to = onelogin_mapping.engineering_role
+  id = "1"
+}
+
+resource "onelogin_mapping" "engineering_role" {
+  actions = [{
+    action = "add_role"
+    value  = ["123"]
+  }]
+  conditions = [{
+    operator = "contains"
+    source   = "member_of"
+    value    = "engineering"
+  }]
+  match = "all"
+  name  = "Engineering Role"
+}
+
+import {
+  to = onelogin_mapping.engineering_role_2
+  id = "2"
+}
+
+resource "onelogin_mapping" "engineering_role_2" {
+  actions = [{
+    action = "set_status"
+    value  = ["2"]
+  }]
+  conditions = [{
+    operator = "ri"
+    source   = "has_role"
+    value    = "456"
+  }]
+  match = "any"
+  name  = "engineering role"
+}
+
+`, string(content))
+}
+
+func TestExportFileOmitsComputed(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	NewOneLoginRoleResource(nil)().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	state, diags := roleToState(ctx, &onelogin.Role{
+		ID:    10,
+		Name:  "1st Role",
+		Apps:  []int64{3},
+		Users: []int64{4, 5},
+	}, types.SetNull(types.Int64Type))
+	require.False(t, diags.HasError(), diags.Errors())
+
+	exported, diags := setExportState(ctx, schemaResp.Schema, "1st Role", 10, state)
+	require.False(t, diags.HasError(), diags.Errors())
+
+	content, err := exportFile("onelogin_role", schemaResp.Schema, []exportedResource{exported})
+	require.NoError(t, err)
+	assert.Equal(t, `import {
+  to = onelogin_role._1st_role
+  id = "10"
+}
+
+resource "onelogin_role" "_1st_role" {
+  apps = [3]
+  name = "1st Role"
+}
+
+`, string(content))
+}
+
+func TestExportApps(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	authResponder, err := httpmock.NewJsonResponder(http.StatusOK, map[string]interface{}{
+		"access_token": "test_access_token",
+		"created_at":   time.Now().UTC(),
+		"expires_in":   3600,
+	})
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodPost, "https://test.onelogin.com/auth/oauth2/v2/token", authResponder)
+
+	app := map[string]interface{}{
+		"id":           1,
+		"name":         "Test App",
+		"connector_id": 108419,
+		"visible":      true,
+	}
+	httpmock.RegisterResponder(http.MethodGet, "https://test.onelogin.com/api/2/apps", func(req *http.Request) (*http.Response, error) {
+		resp, err := httpmock.NewJsonResponse(http.StatusOK, []interface{}{app})
+		resp.Header.Set("Total-Pages", "1")
+		return resp, err
+	})
+	appResponder, err := httpmock.NewJsonResponder(http.StatusOK, app)
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodGet, "https://test.onelogin.com/api/2/apps/1", appResponder)
+
+	client, err := onelogin.NewClient(&onelogin.ClientConfig{
+		ClientID:     "test",
+		ClientSecret: "test",
+		Subdomain:    "test",
+	})
+	require.NoError(t, err)
+
+	files, err := Export(context.Background(), client, []string{"onelogin_app"})
+	require.NoError(t, err)
+	content := string(files["onelogin_app.tf"])
+	assert.Contains(t, content, `resource "onelogin_app" "test_app"`)
+	assert.Regexp(t, `connector_id += 108419`, content)
+	assert.NotContains(t, content, "timeouts")
+}
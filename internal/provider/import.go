@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+)
+
+// importLookupFunc returns the ids of all objects matching the value
+// of a key:value import id, e.g. name:<name>
+type importLookupFunc func(ctx context.Context, value string) ([]int64, error)
+
+// resolveImportID converts an import id into the numeric id of an object.
+//
+// Import ids are either the numeric id of the object or a key:value pair
+// where the key is one of the lookups supported by the resource.  Lookups must
+// match exactly one object, ambiguous values are rejected.
+func resolveImportID(ctx context.Context, kind, id string, lookups map[string]importLookupFunc) (int64, error) {
+	key, value, ok := strings.Cut(id, ":")
+	if !ok {
+		parsed, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected a numeric id or one of %s", importKeysString(lookups))
+		}
+		return parsed, nil
+	}
+
+	lookup, ok := lookups[key]
+	if !ok {
+		return 0, fmt.Errorf("unsupported import key %q, expected a numeric id or one of %s", key, importKeysString(lookups))
+	}
+
+	ids, err := lookup(ctx, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return singleMatch(kind, key, value, ids)
+}
+
+// singleMatch returns the only id in ids or an error naming the
+// lookup if there are no matches or more than one match.
+func singleMatch(kind, key, value string, ids []int64) (int64, error) {
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("no %s found with %s %q", kind, key, value)
+	case 1:
+		return ids[0], nil
+	default:
+		idStrings := make([]string, len(ids))
+		for i, id := range ids {
+			idStrings[i] = strconv.FormatInt(id, 10)
+		}
+		return 0, fmt.Errorf("found %d %ss with %s %q, import by id instead: %s", len(ids), kind, key, value, strings.Join(idStrings, ", "))
+	}
+}
+
+func importKeysString(lookups map[string]importLookupFunc) string {
+	keys := make([]string, 0, len(lookups))
+	for k := range lookups {
+		keys = append(keys, k+":<"+k+">")
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+// List requests used to resolve imports frequently produce 429 and 502 errors.
+const (
+	importListRetry     = 3
+	importListRetryWait = time.Second
+)
+
+func roleIDsByName(client *onelogin.Client) importLookupFunc {
+	return func(ctx context.Context, name string) ([]int64, error) {
+		roles, err := onelogin.ListAll[onelogin.Role](client, &onelogin.Request{
+			Context:   ctx,
+			Method:    onelogin.MethodGet,
+			Path:      onelogin.PathRoles,
+			Retry:     importListRetry,
+			RetryWait: importListRetryWait,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := []int64{}
+		for _, role := range roles {
+			if role.Name == name {
+				ids = append(ids, role.ID)
+			}
+		}
+		return ids, nil
+	}
+}
+
+func appIDsByName(client *onelogin.Client) importLookupFunc {
+	return func(ctx context.Context, name string) ([]int64, error) {
+		apps, err := onelogin.ListAll[onelogin.Application](client, &onelogin.Request{
+			Context:   ctx,
+			Method:    onelogin.MethodGet,
+			Path:      onelogin.PathApps,
+			Retry:     importListRetry,
+			RetryWait: importListRetryWait,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := []int64{}
+		for _, app := range apps {
+			app.UnescapeFields()
+			if app.Name == name {
+				ids = append(ids, app.ID)
+			}
+		}
+		return ids, nil
+	}
+}
+
+// userIDsBy looks up users with the field filter in OneLogin
+// and then confirms the match locally.
+func userIDsBy(client *onelogin.Client, field string, value func(*onelogin.User) string) importLookupFunc {
+	return func(ctx context.Context, v string) ([]int64, error) {
+		users, err := onelogin.ListAll[onelogin.User](client, &onelogin.Request{
+			Context: ctx,
+			Method:  onelogin.MethodGet,
+			Path:    onelogin.PathUsers,
+			QueryParams: onelogin.QueryParams{
+				field: v,
+			},
+			Retry:     importListRetry,
+			RetryWait: importListRetryWait,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := []int64{}
+		for i := range users {
+			if strings.EqualFold(value(&users[i]), v) {
+				ids = append(ids, users[i].ID)
+			}
+		}
+		return ids, nil
+	}
+}
+
+func mappingIDsByName(client *onelogin.Client) importLookupFunc {
+	return func(ctx context.Context, name string) ([]int64, error) {
+		mappings, err := findMappingsByName(ctx, client, name)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]int64, len(mappings))
+		for i, m := range mappings {
+			ids[i] = m.ID
+		}
+		return ids, nil
+	}
+}
+
+// parseChildImportID parses the import id of an object that belongs to
+// a parent object, e.g. <api_authorization_id>/<scope_id>
+func parseChildImportID(id string) (int64, int64, error) {
+	parent, child, ok := strings.Cut(id, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected an id in the format <parent_id>/<id>")
+	}
+
+	parentID, err := strconv.ParseInt(parent, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid parent id %q: %w", parent, err)
+	}
+
+	childID, err := strconv.ParseInt(child, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid id %q: %w", child, err)
+	}
+
+	return parentID, childID, nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveImportID(t *testing.T) {
+	lookups := map[string]importLookupFunc{
+		"name": func(_ context.Context, value string) ([]int64, error) {
+			switch value {
+			case "one":
+				return []int64{1}, nil
+			case "two":
+				return []int64{1, 2}, nil
+			case "error":
+				return nil, fmt.Errorf("lookup failed")
+			default:
+				return []int64{}, nil
+			}
+		},
+		"email": func(_ context.Context, _ string) ([]int64, error) {
+			return []int64{3}, nil
+		},
+	}
+
+	tests := []struct {
+		id          string
+		expected    int64
+		expectedErr string
+	}{
+		{id: "123", expected: 123},
+		{id: "name:one", expected: 1},
+		{id: "email:a:b@example.com", expected: 3},
+		{id: "abc", expectedErr: "expected a numeric id or one of email:<email>, name:<name>"},
+		{id: "username:one", expectedErr: `unsupported import key "username"`},
+		{id: "name:none", expectedErr: `no role found with name "none"`},
+		{id: "name:two", expectedErr: `found 2 roles with name "two", import by id instead: 1, 2`},
+		{id: "name:error", expectedErr: "lookup failed"},
+	}
+
+	for _, test := range tests {
+		id, err := resolveImportID(context.Background(), "role", test.id, lookups)
+		if test.expectedErr != "" {
+			assert.ErrorContains(t, err, test.expectedErr, test.id)
+			continue
+		}
+		assert.NoError(t, err, test.id)
+		assert.Equal(t, test.expected, id, test.id)
+	}
+}
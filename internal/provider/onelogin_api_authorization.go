@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginAPIAuthorizationResource{}
+	_ resource.ResourceWithConfigure   = &oneloginAPIAuthorizationResource{}
+	_ resource.ResourceWithImportState = &oneloginAPIAuthorizationResource{}
+)
+
+type oneloginAPIAuthorizationResource struct {
+	client *onelogin.Client
+}
+
+type oneloginAPIAuthorization struct {
+	ID                            types.Int64  `tfsdk:"id"`
+	Name                          types.String `tfsdk:"name"`
+	Description                   types.String `tfsdk:"description"`
+	ResourceIdentifier            types.String `tfsdk:"resource_identifier"`
+	Audiences                     types.List   `tfsdk:"audiences"`
+	AccessTokenExpirationMinutes  types.Int64  `tfsdk:"access_token_expiration_minutes"`
+	RefreshTokenExpirationMinutes types.Int64  `tfsdk:"refresh_token_expiration_minutes"`
+}
+
+func NewOneLoginAPIAuthorizationResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginAPIAuthorizationResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginAPIAuthorizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_authorization"
+}
+
+func (r *oneloginAPIAuthorizationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginAPIAuthorizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OneLogin API Authorization server. " +
+			"Scopes, claims and client apps are managed with the `onelogin_api_authorization_scope`, " +
+			"`onelogin_api_authorization_claim` and `onelogin_api_authorization_client` resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"resource_identifier": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the API, typically the API url. Used as the default audience.",
+				Required:            true,
+			},
+			"audiences": schema.ListAttribute{
+				MarkdownDescription: "Audiences included in access tokens. Defaults to the resource identifier.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_token_expiration_minutes": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"refresh_token_expiration_minutes": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *oneloginAPIAuthorizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginAPIAuthorization
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created onelogin.APIAuthorization
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      onelogin.PathAPIAuthorizations,
+		Body:      native,
+		RespModel: &created,
+	})
+	if err != nil || created.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error creating api authorization",
+			fmt.Sprintf("Could not create api authorization %s, got error: %v", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginAPIAuthorization
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginAPIAuthorization
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathAPIAuthorizations, id),
+		Body:    native,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating api authorization",
+			fmt.Sprintf("Could not update api authorization %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginAPIAuthorization
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathAPIAuthorizations, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "api authorization to delete not found", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting api authorization",
+			fmt.Sprintf("Could not delete api authorization %v, got error: %s", id, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginAPIAuthorizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(ctx, "api authorization", req.ID, map[string]importLookupFunc{
+		"name": apiAuthorizationIDsByName(r.client),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import api authorization",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginAPIAuthorization{
+		ID: types.Int64Value(id),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationResource) read(ctx context.Context, state *oneloginAPIAuthorization, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueInt64()
+
+	var auth onelogin.APIAuthorization
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v", onelogin.PathAPIAuthorizations, id),
+		RespModel: &auth,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read api authorization %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	newState, diags := apiAuthorizationToState(ctx, &auth)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	diags = respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginAPIAuthorization) toNative(ctx context.Context) (*onelogin.APIAuthorization, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	audiences := []string{}
+	if !state.Audiences.IsNull() && !state.Audiences.IsUnknown() {
+		diags = state.Audiences.ElementsAs(ctx, &audiences, false)
+	}
+
+	return &onelogin.APIAuthorization{
+		Name:        state.Name.ValueString(),
+		Description: state.Description.ValueString(),
+		Configuration: &onelogin.APIAuthorizationConfiguration{
+			ResourceIdentifier:            state.ResourceIdentifier.ValueString(),
+			Audiences:                     audiences,
+			AccessTokenExpirationMinutes:  state.AccessTokenExpirationMinutes.ValueInt64(),
+			RefreshTokenExpirationMinutes: state.RefreshTokenExpirationMinutes.ValueInt64(),
+		},
+	}, diags
+}
+
+func apiAuthorizationToState(ctx context.Context, auth *onelogin.APIAuthorization) (*oneloginAPIAuthorization, diag.Diagnostics) {
+	state := &oneloginAPIAuthorization{
+		ID:          types.Int64Value(auth.ID),
+		Name:        types.StringValue(auth.Name),
+		Description: types.StringNull(),
+	}
+
+	// OneLogin returns an empty description when none is set
+	if auth.Description != "" {
+		state.Description = types.StringValue(auth.Description)
+	}
+
+	config := auth.Configuration
+	if config == nil {
+		config = &onelogin.APIAuthorizationConfiguration{}
+	}
+	state.ResourceIdentifier = types.StringValue(config.ResourceIdentifier)
+	state.AccessTokenExpirationMinutes = types.Int64Value(config.AccessTokenExpirationMinutes)
+	state.RefreshTokenExpirationMinutes = types.Int64Value(config.RefreshTokenExpirationMinutes)
+
+	audiences := config.Audiences
+	if audiences == nil {
+		audiences = []string{}
+	}
+	var diags diag.Diagnostics
+	state.Audiences, diags = types.ListValueFrom(ctx, types.StringType, audiences)
+
+	return state, diags
+}
+
+func apiAuthorizationIDsByName(client *onelogin.Client) importLookupFunc {
+	return func(ctx context.Context, name string) ([]int64, error) {
+		var auths []onelogin.APIAuthorization
+		err := client.ExecRequest(&onelogin.Request{
+			Context:   ctx,
+			Method:    onelogin.MethodGet,
+			Path:      onelogin.PathAPIAuthorizations,
+			RespModel: &auths,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := []int64{}
+		for _, auth := range auths {
+			if auth.Name == name {
+				ids = append(ids, auth.ID)
+			}
+		}
+		return ids, nil
+	}
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginAPIAuthorizationClaimResource{}
+	_ resource.ResourceWithConfigure   = &oneloginAPIAuthorizationClaimResource{}
+	_ resource.ResourceWithImportState = &oneloginAPIAuthorizationClaimResource{}
+)
+
+type oneloginAPIAuthorizationClaimResource struct {
+	client *onelogin.Client
+}
+
+type oneloginAPIAuthorizationClaim struct {
+	ID                    types.Int64  `tfsdk:"id"`
+	APIAuthorizationID    types.Int64  `tfsdk:"api_authorization_id"`
+	Name                  types.String `tfsdk:"name"`
+	UserAttributeMappings types.String `tfsdk:"user_attribute_mappings"`
+	UserAttributeMacros   types.String `tfsdk:"user_attribute_macros"`
+}
+
+func NewOneLoginAPIAuthorizationClaimResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginAPIAuthorizationClaimResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_authorization_claim"
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Custom claim added to access tokens issued by a OneLogin API Authorization server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_authorization_id": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the claim in the access token",
+				Required:            true,
+			},
+			"user_attribute_mappings": schema.StringAttribute{
+				MarkdownDescription: "User attribute used as the claim value, e.g. `email`. Set to `_macro_` to use `user_attribute_macros`.",
+				Optional:            true,
+			},
+			"user_attribute_macros": schema.StringAttribute{
+				MarkdownDescription: "Macro used to build the claim value, e.g. `{firstname} {lastname}`",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginAPIAuthorizationClaim
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+
+	var created onelogin.APIAuthorizationClaim
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      apiAuthorizationClaimsPath(authID),
+		Body:      state.toNative(),
+		RespModel: &created,
+	})
+	if err != nil || created.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error creating api authorization claim",
+			fmt.Sprintf("Could not create claim %s for api authorization %v, got error: %v", state.Name.ValueString(), authID, err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginAPIAuthorizationClaim
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginAPIAuthorizationClaim
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", apiAuthorizationClaimsPath(authID), id),
+		Body:    state.toNative(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating api authorization claim",
+			fmt.Sprintf("Could not update claim %v for api authorization %v, got error: %s", id, authID, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginAPIAuthorizationClaim
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", apiAuthorizationClaimsPath(authID), id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "api authorization claim to delete not found", map[string]interface{}{
+			"api_authorization_id": authID,
+			"id":                   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting api authorization claim",
+			fmt.Sprintf("Could not delete claim %v for api authorization %v, got error: %s", id, authID, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	authID, id, err := parseChildImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import api authorization claim",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginAPIAuthorizationClaim{
+		ID:                 types.Int64Value(id),
+		APIAuthorizationID: types.Int64Value(authID),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClaimResource) read(ctx context.Context, state *oneloginAPIAuthorizationClaim, respState *tfsdk.State, d *diag.Diagnostics) {
+	authID := state.APIAuthorizationID.ValueInt64()
+	id := state.ID.ValueInt64()
+
+	// There is no endpoint for a single claim
+	claims, err := getAPIAuthorizationClaims(ctx, r.client, authID)
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read claims for api authorization %v, got error: %s", authID, err),
+		)
+		return
+	}
+
+	var claim *onelogin.APIAuthorizationClaim
+	for i := range claims {
+		if claims[i].ID == id {
+			claim = &claims[i]
+			break
+		}
+	}
+	if claim == nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read claim %v for api authorization %v, got error: %s", id, authID, onelogin.ErrNotFound),
+		)
+		return
+	}
+
+	newState := oneloginAPIAuthorizationClaim{
+		ID:                    types.Int64Value(claim.ID),
+		APIAuthorizationID:    types.Int64Value(authID),
+		Name:                  types.StringValue(claim.Name),
+		UserAttributeMappings: types.StringNull(),
+		UserAttributeMacros:   types.StringNull(),
+	}
+	if claim.UserAttributeMappings != "" {
+		newState.UserAttributeMappings = types.StringValue(claim.UserAttributeMappings)
+	}
+	if claim.UserAttributeMacros != "" {
+		newState.UserAttributeMacros = types.StringValue(claim.UserAttributeMacros)
+	}
+
+	diags := respState.Set(ctx, &newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginAPIAuthorizationClaim) toNative() *onelogin.APIAuthorizationClaim {
+	return &onelogin.APIAuthorizationClaim{
+		Name:                  state.Name.ValueString(),
+		UserAttributeMappings: state.UserAttributeMappings.ValueString(),
+		UserAttributeMacros:   state.UserAttributeMacros.ValueString(),
+	}
+}
+
+func apiAuthorizationClaimsPath(authID int64) string {
+	return fmt.Sprintf("%s/%v/claims", onelogin.PathAPIAuthorizations, authID)
+}
+
+func getAPIAuthorizationClaims(ctx context.Context, client *onelogin.Client, authID int64) ([]onelogin.APIAuthorizationClaim, error) {
+	var claims []onelogin.APIAuthorizationClaim
+	err := client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      apiAuthorizationClaimsPath(authID),
+		RespModel: &claims,
+	})
+	return claims, err
+}
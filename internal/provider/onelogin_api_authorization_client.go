@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginAPIAuthorizationClientResource{}
+	_ resource.ResourceWithConfigure   = &oneloginAPIAuthorizationClientResource{}
+	_ resource.ResourceWithImportState = &oneloginAPIAuthorizationClientResource{}
+)
+
+type oneloginAPIAuthorizationClientResource struct {
+	client *onelogin.Client
+}
+
+type oneloginAPIAuthorizationClient struct {
+	ID                 types.String `tfsdk:"id"`
+	APIAuthorizationID types.Int64  `tfsdk:"api_authorization_id"`
+	AppID              types.Int64  `tfsdk:"app_id"`
+	Scopes             types.Set    `tfsdk:"scopes"`
+}
+
+func NewOneLoginAPIAuthorizationClientResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginAPIAuthorizationClientResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginAPIAuthorizationClientResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_authorization_client"
+}
+
+func (r *oneloginAPIAuthorizationClientResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginAPIAuthorizationClientResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants an OpenId Connect app access to a OneLogin API Authorization server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "`<api_authorization_id>/<app_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_authorization_id": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"app_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of an OpenId Connect app",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "IDs of the `onelogin_api_authorization_scope` the app is allowed to request",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *oneloginAPIAuthorizationClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginAPIAuthorizationClient
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body.AppID = state.AppID.ValueInt64()
+
+	authID := state.APIAuthorizationID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPost,
+		Path:    apiAuthorizationClientsPath(authID),
+		Body:    body,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating api authorization client",
+			fmt.Sprintf("Could not add app %v to api authorization %v, got error: %s", body.AppID, authID, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClientResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginAPIAuthorizationClient
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClientResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginAPIAuthorizationClient
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+	appID := state.AppID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", apiAuthorizationClientsPath(authID), appID),
+		Body:    body,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating api authorization client",
+			fmt.Sprintf("Could not update app %v scopes for api authorization %v, got error: %s", appID, authID, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginAPIAuthorizationClient
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+	appID := state.AppID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", apiAuthorizationClientsPath(authID), appID),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "api authorization client to delete not found", map[string]interface{}{
+			"api_authorization_id": authID,
+			"app_id":               appID,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting api authorization client",
+			fmt.Sprintf("Could not remove app %v from api authorization %v, got error: %s", appID, authID, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginAPIAuthorizationClientResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	authID, appID, err := parseChildImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import api authorization client",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginAPIAuthorizationClient{
+		APIAuthorizationID: types.Int64Value(authID),
+		AppID:              types.Int64Value(appID),
+		Scopes:             types.SetNull(types.Int64Type),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationClientResource) read(ctx context.Context, state *oneloginAPIAuthorizationClient, respState *tfsdk.State, d *diag.Diagnostics) {
+	authID := state.APIAuthorizationID.ValueInt64()
+	appID := state.AppID.ValueInt64()
+
+	// There is no endpoint for a single client
+	var clients []onelogin.APIAuthorizationClient
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      apiAuthorizationClientsPath(authID),
+		RespModel: &clients,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read clients for api authorization %v, got error: %s", authID, err),
+		)
+		return
+	}
+
+	var client *onelogin.APIAuthorizationClient
+	for i := range clients {
+		if clients[i].AppID == appID {
+			client = &clients[i]
+			break
+		}
+	}
+	if client == nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read app %v for api authorization %v, got error: %s", appID, authID, onelogin.ErrNotFound),
+		)
+		return
+	}
+
+	newState, diags := apiAuthorizationClientToState(ctx, authID, client)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	// Keep an empty set of scopes null if it was not configured
+	if state.Scopes.IsNull() && len(newState.Scopes.Elements()) == 0 {
+		newState.Scopes = types.SetNull(types.Int64Type)
+	}
+
+	diags = respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginAPIAuthorizationClient) toNative(ctx context.Context) (*onelogin.APIAuthorizationClientRequest, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	scopes := []int64{}
+	if !state.Scopes.IsNull() && !state.Scopes.IsUnknown() {
+		diags = state.Scopes.ElementsAs(ctx, &scopes, false)
+	}
+
+	return &onelogin.APIAuthorizationClientRequest{
+		Scopes: scopes,
+	}, diags
+}
+
+func apiAuthorizationClientToState(ctx context.Context, authID int64, client *onelogin.APIAuthorizationClient) (*oneloginAPIAuthorizationClient, diag.Diagnostics) {
+	scopeIDs := make([]int64, len(client.Scopes))
+	for i, scope := range client.Scopes {
+		scopeIDs[i] = scope.ID
+	}
+
+	scopes, diags := types.SetValueFrom(ctx, types.Int64Type, scopeIDs)
+
+	return &oneloginAPIAuthorizationClient{
+		ID:                 types.StringValue(fmt.Sprintf("%v/%v", authID, client.AppID)),
+		APIAuthorizationID: types.Int64Value(authID),
+		AppID:              types.Int64Value(client.AppID),
+		Scopes:             scopes,
+	}, diags
+}
+
+func apiAuthorizationClientsPath(authID int64) string {
+	return fmt.Sprintf("%s/%v/clients", onelogin.PathAPIAuthorizations, authID)
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginAPIAuthorizationScopeResource{}
+	_ resource.ResourceWithConfigure   = &oneloginAPIAuthorizationScopeResource{}
+	_ resource.ResourceWithImportState = &oneloginAPIAuthorizationScopeResource{}
+)
+
+type oneloginAPIAuthorizationScopeResource struct {
+	client *onelogin.Client
+}
+
+type oneloginAPIAuthorizationScope struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	APIAuthorizationID types.Int64  `tfsdk:"api_authorization_id"`
+	Value              types.String `tfsdk:"value"`
+	Description        types.String `tfsdk:"description"`
+}
+
+func NewOneLoginAPIAuthorizationScopeResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginAPIAuthorizationScopeResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_authorization_scope"
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Scope of a OneLogin API Authorization server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_authorization_id": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Scope value requested by clients, e.g. `read:users`",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginAPIAuthorizationScope
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+
+	var created onelogin.APIAuthorizationScope
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      apiAuthorizationScopesPath(authID),
+		Body:      state.toNative(),
+		RespModel: &created,
+	})
+	if err != nil || created.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error creating api authorization scope",
+			fmt.Sprintf("Could not create scope %s for api authorization %v, got error: %v", state.Value.ValueString(), authID, err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginAPIAuthorizationScope
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginAPIAuthorizationScope
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", apiAuthorizationScopesPath(authID), id),
+		Body:    state.toNative(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating api authorization scope",
+			fmt.Sprintf("Could not update scope %v for api authorization %v, got error: %s", id, authID, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginAPIAuthorizationScope
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authID := state.APIAuthorizationID.ValueInt64()
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", apiAuthorizationScopesPath(authID), id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "api authorization scope to delete not found", map[string]interface{}{
+			"api_authorization_id": authID,
+			"id":                   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting api authorization scope",
+			fmt.Sprintf("Could not delete scope %v for api authorization %v, got error: %s", id, authID, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	authID, id, err := parseChildImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import api authorization scope",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginAPIAuthorizationScope{
+		ID:                 types.Int64Value(id),
+		APIAuthorizationID: types.Int64Value(authID),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginAPIAuthorizationScopeResource) read(ctx context.Context, state *oneloginAPIAuthorizationScope, respState *tfsdk.State, d *diag.Diagnostics) {
+	authID := state.APIAuthorizationID.ValueInt64()
+	id := state.ID.ValueInt64()
+
+	// There is no endpoint for a single scope
+	scopes, err := getAPIAuthorizationScopes(ctx, r.client, authID)
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read scopes for api authorization %v, got error: %s", authID, err),
+		)
+		return
+	}
+
+	var scope *onelogin.APIAuthorizationScope
+	for i := range scopes {
+		if scopes[i].ID == id {
+			scope = &scopes[i]
+			break
+		}
+	}
+	if scope == nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read scope %v for api authorization %v, got error: %s", id, authID, onelogin.ErrNotFound),
+		)
+		return
+	}
+
+	newState := oneloginAPIAuthorizationScope{
+		ID:                 types.Int64Value(scope.ID),
+		APIAuthorizationID: types.Int64Value(authID),
+		Value:              types.StringValue(scope.Value),
+		Description:        types.StringNull(),
+	}
+	if scope.Description != "" {
+		newState.Description = types.StringValue(scope.Description)
+	}
+
+	diags := respState.Set(ctx, &newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginAPIAuthorizationScope) toNative() *onelogin.APIAuthorizationScope {
+	return &onelogin.APIAuthorizationScope{
+		Value:       state.Value.ValueString(),
+		Description: state.Description.ValueString(),
+	}
+}
+
+func apiAuthorizationScopesPath(authID int64) string {
+	return fmt.Sprintf("%s/%v/scopes", onelogin.PathAPIAuthorizations, authID)
+}
+
+func getAPIAuthorizationScopes(ctx context.Context, client *onelogin.Client, authID int64) ([]onelogin.APIAuthorizationScope, error) {
+	var scopes []onelogin.APIAuthorizationScope
+	err := client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      apiAuthorizationScopesPath(authID),
+		RespModel: &scopes,
+	})
+	return scopes, err
+}
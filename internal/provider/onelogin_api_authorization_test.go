@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *providerTestSuite) TestAccResourceAPIAuthorization() {
+	name := "test_api_auth_" + s.randString()
+
+	config := func(description, scopeDescription string) string {
+		return s.providerConfig + fmt.Sprintf(`
+			resource "onelogin_api_authorization" "test" {
+				name                = "%[1]v"
+				description         = "%[2]v"
+				resource_identifier = "https://%[1]v.example.com"
+			}
+
+			resource "onelogin_api_authorization_scope" "test" {
+				api_authorization_id = onelogin_api_authorization.test.id
+				value                = "read:%[1]v"
+				description          = "%[3]v"
+			}
+
+			resource "onelogin_api_authorization_claim" "test" {
+				api_authorization_id    = onelogin_api_authorization.test.id
+				name                    = "%[1]v_email"
+				user_attribute_mappings = "email"
+			}
+
+			# OpenId Connect (OIDC) connector
+			resource "onelogin_app" "test" {
+				name         = "%[1]v"
+				connector_id = 108419
+			}
+
+			resource "onelogin_api_authorization_client" "test" {
+				api_authorization_id = onelogin_api_authorization.test.id
+				app_id               = onelogin_app.test.id
+				scopes               = [onelogin_api_authorization_scope.test.id]
+			}
+		`, name, description, scopeDescription)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("created", "read"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_api_authorization.test", "name", name),
+					resource.TestCheckResourceAttr("onelogin_api_authorization.test", "description", "created"),
+					resource.TestCheckResourceAttrSet("onelogin_api_authorization.test", "access_token_expiration_minutes"),
+					resource.TestCheckResourceAttr("onelogin_api_authorization_scope.test", "value", "read:"+name),
+					resource.TestCheckResourceAttr("onelogin_api_authorization_claim.test", "user_attribute_mappings", "email"),
+					resource.TestCheckResourceAttr("onelogin_api_authorization_client.test", "scopes.#", "1"),
+					resource.TestCheckResourceAttrPair("onelogin_api_authorization_client.test", "scopes.0", "onelogin_api_authorization_scope.test", "id"),
+				),
+			},
+			{
+				Config: config("updated", "read updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_api_authorization.test", "description", "updated"),
+					resource.TestCheckResourceAttr("onelogin_api_authorization_scope.test", "description", "read updated"),
+				),
+			},
+			{
+				ResourceName:      "onelogin_api_authorization.test",
+				ImportState:       true,
+				ImportStateId:     "name:" + name,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "onelogin_api_authorization_scope.test",
+				ImportState:       true,
+				ImportStateIdFunc: childImportIDFunc("onelogin_api_authorization_scope.test", "api_authorization_id", "id"),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "onelogin_api_authorization_claim.test",
+				ImportState:       true,
+				ImportStateIdFunc: childImportIDFunc("onelogin_api_authorization_claim.test", "api_authorization_id", "id"),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "onelogin_api_authorization_client.test",
+				ImportState:       true,
+				ImportStateIdFunc: childImportIDFunc("onelogin_api_authorization_client.test", "api_authorization_id", "app_id"),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "onelogin_api_authorization_scope.test",
+				ImportState:   true,
+				ImportStateId: "1234",
+				ExpectError:   regexp.MustCompile("expected an id in the format"),
+			},
+		},
+	})
+}
+
+func TestAPIAuthorizationToState(t *testing.T) {
+	ctx := context.Background()
+
+	state, diags := apiAuthorizationToState(ctx, &onelogin.APIAuthorization{
+		ID:   1234,
+		Name: "test",
+		Configuration: &onelogin.APIAuthorizationConfiguration{
+			ResourceIdentifier:            "https://api.example.com",
+			Audiences:                     []string{"https://api.example.com"},
+			AccessTokenExpirationMinutes:  10,
+			RefreshTokenExpirationMinutes: 20,
+		},
+	})
+	require.False(t, diags.HasError(), diags.Errors())
+
+	assert.Equal(t, int64(1234), state.ID.ValueInt64())
+	assert.Equal(t, "test", state.Name.ValueString())
+	assert.True(t, state.Description.IsNull())
+	assert.Equal(t, "https://api.example.com", state.ResourceIdentifier.ValueString())
+	assert.Equal(t, int64(10), state.AccessTokenExpirationMinutes.ValueInt64())
+	assert.Equal(t, int64(20), state.RefreshTokenExpirationMinutes.ValueInt64())
+
+	audiences := []string{}
+	diags = state.Audiences.ElementsAs(ctx, &audiences, false)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, []string{"https://api.example.com"}, audiences)
+
+	native, diags := state.toNative(ctx)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, "", native.Description)
+	assert.Equal(t, "https://api.example.com", native.Configuration.ResourceIdentifier)
+	assert.Equal(t, int64(10), native.Configuration.AccessTokenExpirationMinutes)
+}
+
+func TestParseChildImportID(t *testing.T) {
+	parentID, id, err := parseChildImportID("123/456")
+	require.NoError(t, err)
+	assert.Equal(t, int64(123), parentID)
+	assert.Equal(t, int64(456), id)
+
+	for _, invalid := range []string{"123", "abc/456", "123/abc", "123/"} {
+		_, _, err = parseChildImportID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -56,6 +56,11 @@ type oneloginApp struct {
 	Configuration types.Dynamic `tfsdk:"configuration"`
 
 	Parameters types.Map `tfsdk:"parameters"`
+
+	// AdoptExisting attribute local to terraform object
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+
+	Timeouts types.Object `tfsdk:"timeouts"`
 }
 
 type oneloginAppParameter struct {
@@ -208,10 +213,12 @@ func (d *oneloginAppResource) Schema(ctx context.Context, req resource.SchemaReq
 				Optional: true,
 			},
 			"policy_id": schema.Int64Attribute{
-				Optional: true,
+				MarkdownDescription: "ID of the app policy, see the `onelogin_policy` data source to look up a policy by name",
+				Optional:            true,
 			},
 			"brand_id": schema.Int64Attribute{
-				Optional: true,
+				MarkdownDescription: "ID of the `onelogin_brand` used for the login page of the app",
+				Optional:            true,
 			},
 
 			"provisioning_enabled": schema.BoolAttribute{
@@ -358,6 +365,12 @@ func (d *oneloginAppResource) Schema(ctx context.Context, req resource.SchemaReq
 				Optional: true,
 				Computed: true,
 			},
+
+			// Note: attribute local to terraform objects
+			"adopt_existing": adoptExistingAttribute("app"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
@@ -370,6 +383,33 @@ func (d *oneloginAppResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutCreate, &resp.Diagnostics)
+	defer cancel()
+
+	// The existing apps with the name are never mistaken for the app
+	// created by a failed create, they are only adopted if configured.
+	name := state.Name.ValueString()
+	reconciler := newCreateReconciler("app", name, appIDsByName(d.client))
+	if state.AdoptExisting.ValueBool() {
+		reconciler.collect(ctx)
+		id, err := reconciler.adoptable()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error adopting app",
+				"Could not adopt existing app: "+err.Error(),
+			)
+			return
+		}
+		if id != 0 {
+			tflog.Info(ctx, "adopting existing app", map[string]interface{}{
+				"name": name,
+				"id":   id,
+			})
+			d.adopt(ctx, id, &state, resp)
+			return
+		}
+	}
+
 	app, diags := state.toNativApp(ctx)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -384,6 +424,28 @@ func (d *oneloginAppResource) Create(ctx context.Context, req resource.CreateReq
 		Body:      app,
 		RespModel: &appResp,
 	})
+	if err != nil && onelogin.MayHaveSucceeded(err) {
+		// OneLogin may finish the create after the request failed,
+		// adopt the app so that the next apply doesn't create a duplicate.
+		reconcileCtx, reconcileCancel := reconcileContext(ctx)
+		defer reconcileCancel()
+
+		id, lookupErr := reconciler.created(reconcileCtx)
+		if lookupErr != nil {
+			resp.Diagnostics.AddError(
+				"Error creating app",
+				orphanDetail("app", name, err, lookupErr),
+			)
+			return
+		}
+		tflog.Warn(reconcileCtx, "adopting app created by failed request", map[string]interface{}{
+			"name":  name,
+			"id":    id,
+			"error": err.Error(),
+		})
+		d.adopt(reconcileCtx, id, &state, resp)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating app",
@@ -392,13 +454,18 @@ func (d *oneloginAppResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// State is set even if the app is not consistent so that it's tracked
+	d.waitForApp(ctx, &appResp, &resp.Diagnostics)
+
 	appResp.UnescapeFields()
 
 	newState, diags := appToState(ctx, &appResp)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	if diags.HasError() {
 		return
 	}
+	newState.AdoptExisting = state.AdoptExisting
+	newState.Timeouts = state.Timeouts
 
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
@@ -407,6 +474,21 @@ func (d *oneloginAppResource) Create(ctx context.Context, req resource.CreateReq
 	}
 }
 
+// adopt takes over the existing app with the id and updates it to
+// match the plan.
+func (d *oneloginAppResource) adopt(ctx context.Context, id int64, plan *oneloginApp, resp *resource.CreateResponse) {
+	plan.ID = types.Int64Value(id)
+
+	newState, diags := d.put(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
 func (d *oneloginAppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state oneloginApp
 	diags := req.State.Get(ctx, &state)
@@ -415,6 +497,9 @@ func (d *oneloginAppResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutRead, &resp.Diagnostics)
+	defer cancel()
+
 	d.read(ctx, &state, &resp.State, &resp.Diagnostics)
 }
 
@@ -426,40 +511,59 @@ func (d *oneloginAppResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	nativeApp, diags := state.toNativApp(ctx)
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutUpdate, &resp.Diagnostics)
+	defer cancel()
+
+	newState, diags := d.put(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+
+	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+}
+
+// put replaces the app with the planned app.  The new state is returned
+// with an error if the app is not consistent in time.
+func (d *oneloginAppResource) put(ctx context.Context, plan *oneloginApp) (*oneloginApp, diag.Diagnostics) {
+	nativeApp, diags := plan.toNativApp(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
 
 	var appResp onelogin.Application
 	err := d.client.ExecRequest(&onelogin.Request{
 		Context:   ctx,
 		Method:    onelogin.MethodPut,
-		Path:      fmt.Sprintf("%s/%v", onelogin.PathApps, state.ID.ValueInt64()),
+		Path:      fmt.Sprintf("%s/%v", onelogin.PathApps, plan.ID.ValueInt64()),
 		Body:      nativeApp,
 		RespModel: &appResp,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error updating app",
 			"Could not update app: "+err.Error(),
 		)
-		return
+		return nil, diags
 	}
 
+	d.waitForApp(ctx, &appResp, &diags)
+
 	appResp.UnescapeFields()
 
-	newState, diags := appToState(ctx, &appResp)
-	if diags.HasError() {
-		return
+	newState, newDiags := appToState(ctx, &appResp)
+	diags.Append(newDiags...)
+	if newDiags.HasError() {
+		return nil, diags
 	}
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.Timeouts = plan.Timeouts
 
-	diags = resp.State.Set(ctx, &newState)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	return newState, diags
 }
 
 func (d *oneloginAppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -470,6 +574,9 @@ func (d *oneloginAppResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutDelete, &resp.Diagnostics)
+	defer cancel()
+
 	err := d.client.ExecRequest(&onelogin.Request{
 		Context: ctx,
 		Method:  onelogin.MethodDelete,
@@ -495,7 +602,9 @@ func (d *oneloginAppResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (d *oneloginAppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	id, err := resolveImportID(ctx, "app", req.ID, map[string]importLookupFunc{
+		"name": appIDsByName(d.client),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error parsing ID for import app",
@@ -505,7 +614,8 @@ func (d *oneloginAppResource) ImportState(ctx context.Context, req resource.Impo
 	}
 
 	state := oneloginApp{
-		ID: types.Int64Value(int64(id)),
+		ID:       types.Int64Value(id),
+		Timeouts: timeoutsNull(),
 	}
 
 	d.read(ctx, &state, &resp.State, &resp.Diagnostics)
@@ -537,12 +647,39 @@ func (r *oneloginAppResource) read(ctx context.Context, state *oneloginApp, resp
 	if d.HasError() {
 		return
 	}
+	newState.AdoptExisting = state.AdoptExisting
+	newState.Timeouts = state.Timeouts
 
 	// Update state
 	diags = respState.Set(ctx, newState)
 	d.Append(diags...)
 }
 
+// waitForApp reads the app until it reflects the write that returned
+// expected.  Reads right after a write may return the previous app.
+func (d *oneloginAppResource) waitForApp(ctx context.Context, expected *onelogin.Application, diags *diag.Diagnostics) {
+	_, err := onelogin.WaitUntilConsistent(d.client, &onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodGet,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathApps, expected.ID),
+	}, func(app *onelogin.Application) bool {
+		return appConsistent(app, expected)
+	})
+	if err != nil {
+		addConsistencyError(diags, "app", expected.ID, err)
+	}
+}
+
+func appConsistent(app, expected *onelogin.Application) bool {
+	return app.Name == expected.Name &&
+		app.Visible == expected.Visible &&
+		equalPointers(app.Description, expected.Description) &&
+		equalPointers(app.Notes, expected.Notes) &&
+		equalPointers(app.TabID, expected.TabID) &&
+		equalPointers(app.BrandID, expected.BrandID) &&
+		equalPointers(app.PolicyID, expected.PolicyID)
+}
+
 func (state *oneloginApp) toNativApp(ctx context.Context) (*onelogin.Application, diag.Diagnostics) {
 	app := &onelogin.Application{
 		ID:                    state.ID.ValueInt64(),
@@ -661,6 +798,9 @@ func appToState(ctx context.Context, app *onelogin.Application) (*oneloginApp, d
 		BrandID:     types.Int64PointerValue(app.BrandID),
 		Notes:       types.StringPointerValue(app.Notes),
 		PolicyID:    types.Int64PointerValue(app.PolicyID),
+
+		AdoptExisting: types.BoolNull(),
+		Timeouts:      timeoutsNull(),
 	}
 
 	if app.Provisioning != nil {
@@ -7,7 +7,6 @@ import (
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
 	fres "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -34,7 +33,7 @@ func (s *providerTestSuite) TestAccResourceAppMisc() {
 
 // TODO: test configuration blocks for each app type
 func (s *providerTestSuite) TestAccResourceApp() {
-	name := "test_app_" + acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	name := "test_app_" + s.randString()
 	connectorID := "110016"
 
 	checkRegex := func(r string) func(string) error {
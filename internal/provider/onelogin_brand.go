@@ -0,0 +1,512 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &oneloginBrandResource{}
+	_ resource.ResourceWithConfigure      = &oneloginBrandResource{}
+	_ resource.ResourceWithImportState    = &oneloginBrandResource{}
+	_ resource.ResourceWithValidateConfig = &oneloginBrandResource{}
+)
+
+type oneloginBrandResource struct {
+	client *onelogin.Client
+}
+
+type oneloginBrand struct {
+	ID                              types.Int64  `tfsdk:"id"`
+	Name                            types.String `tfsdk:"name"`
+	Enabled                         types.Bool   `tfsdk:"enabled"`
+	CustomSupportEnabled            types.Bool   `tfsdk:"custom_support_enabled"`
+	CustomColor                     types.String `tfsdk:"custom_color"`
+	CustomAccentColor               types.String `tfsdk:"custom_accent_color"`
+	CustomMaskingColor              types.String `tfsdk:"custom_masking_color"`
+	CustomMaskingOpacity            types.Int64  `tfsdk:"custom_masking_opacity"`
+	EnableCustomLabelForLoginScreen types.Bool   `tfsdk:"enable_custom_label_for_login_screen"`
+	CustomLabelTextForLoginScreen   types.String `tfsdk:"custom_label_text_for_login_screen"`
+	LoginInstructionTitle           types.String `tfsdk:"login_instruction_title"`
+	LoginInstruction                types.String `tfsdk:"login_instruction"`
+	HideOneLoginFooter              types.Bool   `tfsdk:"hide_onelogin_footer"`
+	MFAEnrollmentMessage            types.String `tfsdk:"mfa_enrollment_message"`
+
+	LogoFile         types.String `tfsdk:"logo_file"`
+	LogoBase64       types.String `tfsdk:"logo_base64"`
+	LogoURL          types.String `tfsdk:"logo_url"`
+	BackgroundFile   types.String `tfsdk:"background_file"`
+	BackgroundBase64 types.String `tfsdk:"background_base64"`
+	BackgroundURL    types.String `tfsdk:"background_url"`
+}
+
+func NewOneLoginBrandResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginBrandResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginBrandResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_brand"
+}
+
+func (r *oneloginBrandResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginBrandResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	optionalBool := func() schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OneLogin Brand used to customize the login page of apps. " +
+			"Message templates of the brand are managed with `onelogin_brand_template`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"enabled":                              optionalBool(),
+			"custom_support_enabled":               optionalBool(),
+			"enable_custom_label_for_login_screen": optionalBool(),
+			"hide_onelogin_footer":                 optionalBool(),
+			"custom_color": schema.StringAttribute{
+				MarkdownDescription: "Primary color as a hex code, e.g. `#3C5A99`",
+				Optional:            true,
+			},
+			"custom_accent_color": schema.StringAttribute{
+				Optional: true,
+			},
+			"custom_masking_color": schema.StringAttribute{
+				Optional: true,
+			},
+			"custom_masking_opacity": schema.Int64Attribute{
+				MarkdownDescription: "Opacity of the background mask, 0 to 100",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"custom_label_text_for_login_screen": schema.StringAttribute{
+				Optional: true,
+			},
+			"login_instruction_title": schema.StringAttribute{
+				Optional: true,
+			},
+			"login_instruction": schema.StringAttribute{
+				Optional: true,
+			},
+			"mfa_enrollment_message": schema.StringAttribute{
+				Optional: true,
+			},
+			"logo_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the logo image. Changes to the file are only detected when the path changes, " +
+					"use `logo_base64 = filebase64(...)` to track the content. Conflicts with `logo_base64`.",
+				Optional: true,
+			},
+			"logo_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded logo image. Conflicts with `logo_file`.",
+				Optional:            true,
+			},
+			"logo_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					imageURLModifier{image: "logo"},
+				},
+			},
+			"background_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the login page background image. Conflicts with `background_base64`.",
+				Optional:            true,
+			},
+			"background_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded login page background image. Conflicts with `background_file`.",
+				Optional:            true,
+			},
+			"background_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					imageURLModifier{image: "background"},
+				},
+			},
+		},
+	}
+}
+
+func (r *oneloginBrandResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config oneloginBrand
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, image := range []struct {
+		name         string
+		file, base64 types.String
+	}{
+		{"logo", config.LogoFile, config.LogoBase64},
+		{"background", config.BackgroundFile, config.BackgroundBase64},
+	} {
+		if !image.file.IsNull() && !image.base64.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(image.name+"_file"),
+				"Conflicting brand image",
+				fmt.Sprintf("Only one of %[1]s_file and %[1]s_base64 can be set", image.name),
+			)
+		}
+	}
+}
+
+func (r *oneloginBrandResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginBrand
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, err := state.toNative()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading brand image", err.Error())
+		return
+	}
+
+	var created onelogin.Brand
+	err = r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      onelogin.PathBrands,
+		Body:      native,
+		RespModel: &created,
+	})
+	if err != nil || created.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error creating brand",
+			fmt.Sprintf("Could not create brand %s, got error: %v", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginBrand
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, prior oneloginBrand
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, err := state.toNative()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading brand image", err.Error())
+		return
+	}
+
+	// Empty images are left unchanged, images removed from config are cleared
+	native.ClearLogo = imageRemoved(prior.LogoFile, prior.LogoBase64, state.LogoFile, state.LogoBase64)
+	native.ClearBackground = imageRemoved(prior.BackgroundFile, prior.BackgroundBase64, state.BackgroundFile, state.BackgroundBase64)
+
+	id := state.ID.ValueInt64()
+	err = r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathBrands, id),
+		Body:    native,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating brand",
+			fmt.Sprintf("Could not update brand %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginBrand
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathBrands, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "brand to delete not found", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting brand",
+			fmt.Sprintf("Could not delete brand %v, got error: %s", id, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginBrandResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(ctx, "brand", req.ID, map[string]importLookupFunc{
+		"name": brandIDsByName(r.client),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import brand",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginBrand{
+		ID:               types.Int64Value(id),
+		LogoFile:         types.StringNull(),
+		LogoBase64:       types.StringNull(),
+		BackgroundFile:   types.StringNull(),
+		BackgroundBase64: types.StringNull(),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandResource) read(ctx context.Context, state *oneloginBrand, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueInt64()
+
+	var brand onelogin.Brand
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v", onelogin.PathBrands, id),
+		RespModel: &brand,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read brand %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	newState := brandToState(&brand)
+
+	// Images are never returned, keep the configured source
+	newState.LogoFile = state.LogoFile
+	newState.LogoBase64 = state.LogoBase64
+	newState.BackgroundFile = state.BackgroundFile
+	newState.BackgroundBase64 = state.BackgroundBase64
+
+	diags := respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginBrand) toNative() (*onelogin.BrandRequest, error) {
+	logo, err := brandImage(state.LogoFile, state.LogoBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	background, err := brandImage(state.BackgroundFile, state.BackgroundBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &onelogin.BrandRequest{
+		Brand: onelogin.Brand{
+			Name:                            state.Name.ValueString(),
+			Enabled:                         state.Enabled.ValueBool(),
+			CustomSupportEnabled:            state.CustomSupportEnabled.ValueBool(),
+			CustomColor:                     state.CustomColor.ValueString(),
+			CustomAccentColor:               state.CustomAccentColor.ValueString(),
+			CustomMaskingColor:              state.CustomMaskingColor.ValueString(),
+			CustomMaskingOpacity:            state.CustomMaskingOpacity.ValueInt64(),
+			EnableCustomLabelForLoginScreen: state.EnableCustomLabelForLoginScreen.ValueBool(),
+			CustomLabelTextForLoginScreen:   state.CustomLabelTextForLoginScreen.ValueString(),
+			LoginInstructionTitle:           state.LoginInstructionTitle.ValueString(),
+			LoginInstruction:                state.LoginInstruction.ValueString(),
+			HideOneLoginFooter:              state.HideOneLoginFooter.ValueBool(),
+			MFAEnrollmentMessage:            state.MFAEnrollmentMessage.ValueString(),
+		},
+		Logo:       logo,
+		Background: background,
+	}, nil
+}
+
+// brandImage returns the base64 encoded image from either a file path or an
+// already encoded value.  An empty string leaves the image unchanged.
+func brandImage(file, encoded types.String) (string, error) {
+	if !file.IsNull() && !file.IsUnknown() {
+		content, err := os.ReadFile(file.ValueString())
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(content), nil
+	}
+
+	if !encoded.IsNull() && !encoded.IsUnknown() {
+		if _, err := base64.StdEncoding.DecodeString(encoded.ValueString()); err != nil {
+			return "", fmt.Errorf("image is not base64 encoded: %w", err)
+		}
+		return encoded.ValueString(), nil
+	}
+
+	return "", nil
+}
+
+// imageRemoved reports whether an image that was set is no longer configured
+func imageRemoved(priorFile, priorBase64, file, encoded types.String) bool {
+	wasSet := !priorFile.IsNull() || !priorBase64.IsNull()
+	return wasSet && file.IsNull() && encoded.IsNull()
+}
+
+// imageURLModifier plans the url of a brand image as unknown when the
+// image changes.  Otherwise the url is kept by UseStateForUnknown.
+type imageURLModifier struct {
+	image string
+}
+
+func (m imageURLModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("The url is unknown when %[1]s_file or %[1]s_base64 changes", m.image)
+}
+
+func (m imageURLModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m imageURLModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, attr := range []string{m.image + "_file", m.image + "_base64"} {
+		var planned, prior types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr), &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attr), &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !planned.Equal(prior) {
+			resp.PlanValue = types.StringUnknown()
+			return
+		}
+	}
+}
+
+func brandToState(brand *onelogin.Brand) *oneloginBrand {
+	// OneLogin returns empty strings for unset text
+	optionalString := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+
+	state := &oneloginBrand{
+		ID:                              types.Int64Value(brand.ID),
+		Name:                            types.StringValue(brand.Name),
+		Enabled:                         types.BoolValue(brand.Enabled),
+		CustomSupportEnabled:            types.BoolValue(brand.CustomSupportEnabled),
+		CustomColor:                     optionalString(brand.CustomColor),
+		CustomAccentColor:               optionalString(brand.CustomAccentColor),
+		CustomMaskingColor:              optionalString(brand.CustomMaskingColor),
+		CustomMaskingOpacity:            types.Int64Value(brand.CustomMaskingOpacity),
+		EnableCustomLabelForLoginScreen: types.BoolValue(brand.EnableCustomLabelForLoginScreen),
+		CustomLabelTextForLoginScreen:   optionalString(brand.CustomLabelTextForLoginScreen),
+		LoginInstructionTitle:           optionalString(brand.LoginInstructionTitle),
+		LoginInstruction:                optionalString(brand.LoginInstruction),
+		HideOneLoginFooter:              types.BoolValue(brand.HideOneLoginFooter),
+		MFAEnrollmentMessage:            optionalString(brand.MFAEnrollmentMessage),
+		LogoURL:                         types.StringNull(),
+		BackgroundURL:                   types.StringNull(),
+	}
+
+	if brand.Logo != nil {
+		state.LogoURL = optionalString(brand.Logo.OriginalURL)
+	}
+	if brand.Background != nil {
+		state.BackgroundURL = optionalString(brand.Background.OriginalURL)
+	}
+
+	return state
+}
+
+func brandIDsByName(client *onelogin.Client) importLookupFunc {
+	return func(ctx context.Context, name string) ([]int64, error) {
+		var brands []onelogin.Brand
+		err := client.ExecRequest(&onelogin.Request{
+			Context:   ctx,
+			Method:    onelogin.MethodGet,
+			Path:      onelogin.PathBrands,
+			RespModel: &brands,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := []int64{}
+		for _, brand := range brands {
+			if brand.Name == name {
+				ids = append(ids, brand.ID)
+			}
+		}
+		return ids, nil
+	}
+}
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginBrandTemplateResource{}
+	_ resource.ResourceWithConfigure   = &oneloginBrandTemplateResource{}
+	_ resource.ResourceWithImportState = &oneloginBrandTemplateResource{}
+)
+
+type oneloginBrandTemplateResource struct {
+	client *onelogin.Client
+}
+
+type oneloginBrandTemplate struct {
+	ID      types.Int64  `tfsdk:"id"`
+	BrandID types.Int64  `tfsdk:"brand_id"`
+	Type    types.String `tfsdk:"type"`
+	Locale  types.String `tfsdk:"locale"`
+	Subject types.String `tfsdk:"subject"`
+	HTML    types.String `tfsdk:"html"`
+	Plain   types.String `tfsdk:"plain"`
+	Message types.String `tfsdk:"message"`
+}
+
+func NewOneLoginBrandTemplateResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginBrandTemplateResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginBrandTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_brand_template"
+}
+
+func (r *oneloginBrandTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginBrandTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Email or SMS message template of a OneLogin Brand. " +
+			"Email templates use `subject`, `html` and `plain`, SMS templates use `message`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"brand_id": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Template type, e.g. `email_forgot_password` or `sms_otp`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"locale": schema.StringAttribute{
+				MarkdownDescription: "Two letter language code, e.g. `en`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subject": schema.StringAttribute{
+				Optional: true,
+			},
+			"html": schema.StringAttribute{
+				Optional: true,
+			},
+			"plain": schema.StringAttribute{
+				Optional: true,
+			},
+			"message": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *oneloginBrandTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginBrandTemplate
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	brandID := state.BrandID.ValueInt64()
+
+	var created onelogin.BrandTemplate
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      brandTemplatesPath(brandID),
+		Body:      state.toNative(),
+		RespModel: &created,
+	})
+	if err != nil || created.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error creating brand template",
+			fmt.Sprintf("Could not create %s template for brand %v, got error: %v", state.Type.ValueString(), brandID, err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginBrandTemplate
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginBrandTemplate
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	brandID := state.BrandID.ValueInt64()
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", brandTemplatesPath(brandID), id),
+		Body:    state.toNative(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating brand template",
+			fmt.Sprintf("Could not update template %v for brand %v, got error: %s", id, brandID, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginBrandTemplate
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	brandID := state.BrandID.ValueInt64()
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", brandTemplatesPath(brandID), id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "brand template to delete not found", map[string]interface{}{
+			"brand_id": brandID,
+			"id":       id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting brand template",
+			fmt.Sprintf("Could not delete template %v for brand %v, got error: %s", id, brandID, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginBrandTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	brandID, id, err := parseChildImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import brand template",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginBrandTemplate{
+		ID:      types.Int64Value(id),
+		BrandID: types.Int64Value(brandID),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginBrandTemplateResource) read(ctx context.Context, state *oneloginBrandTemplate, respState *tfsdk.State, d *diag.Diagnostics) {
+	brandID := state.BrandID.ValueInt64()
+	id := state.ID.ValueInt64()
+
+	var template onelogin.BrandTemplate
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v", brandTemplatesPath(brandID), id),
+		RespModel: &template,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read template %v for brand %v, got error: %s", id, brandID, err),
+		)
+		return
+	}
+
+	diags := respState.Set(ctx, brandTemplateToState(brandID, &template))
+	d.Append(diags...)
+}
+
+func (state *oneloginBrandTemplate) toNative() *onelogin.BrandTemplate {
+	return &onelogin.BrandTemplate{
+		Type:   state.Type.ValueString(),
+		Locale: state.Locale.ValueString(),
+		Template: onelogin.BrandTemplateContent{
+			Subject: state.Subject.ValueString(),
+			HTML:    state.HTML.ValueString(),
+			Plain:   state.Plain.ValueString(),
+			Message: state.Message.ValueString(),
+		},
+	}
+}
+
+func brandTemplateToState(brandID int64, template *onelogin.BrandTemplate) *oneloginBrandTemplate {
+	// Email templates have no message and sms templates have no subject,
+	// html or plain text
+	optionalString := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+
+	return &oneloginBrandTemplate{
+		ID:      types.Int64Value(template.ID),
+		BrandID: types.Int64Value(brandID),
+		Type:    types.StringValue(template.Type),
+		Locale:  types.StringValue(template.Locale),
+		Subject: optionalString(template.Template.Subject),
+		HTML:    optionalString(template.Template.HTML),
+		Plain:   optionalString(template.Template.Plain),
+		Message: optionalString(template.Template.Message),
+	}
+}
+
+func brandTemplatesPath(brandID int64) string {
+	return fmt.Sprintf("%s/%v/templates", onelogin.PathBrands, brandID)
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 1x1 transparent png
+const testBrandImage = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII="
+
+func (s *providerTestSuite) TestAccResourceBrand() {
+	name := "test_brand_" + s.randString()
+
+	config := func(color, subject string) string {
+		return s.providerConfig + fmt.Sprintf(`
+			resource "onelogin_brand" "test" {
+				name         = "%[1]v"
+				enabled      = true
+				custom_color = "%[2]v"
+				logo_base64  = "%[4]v"
+			}
+
+			resource "onelogin_brand_template" "test" {
+				brand_id = onelogin_brand.test.id
+				type     = "email_forgot_password"
+				locale   = "en"
+				subject  = "%[3]v"
+				html     = "<p>%[3]v</p>"
+				plain    = "%[3]v"
+			}
+		`, name, color, subject, testBrandImage)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("#3C5A99", "created"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_brand.test", "name", name),
+					resource.TestCheckResourceAttr("onelogin_brand.test", "custom_color", "#3C5A99"),
+					resource.TestCheckResourceAttrSet("onelogin_brand.test", "logo_url"),
+					resource.TestCheckResourceAttr("onelogin_brand_template.test", "subject", "created"),
+					resource.TestCheckNoResourceAttr("onelogin_brand_template.test", "message"),
+				),
+			},
+			{
+				Config: config("#FF9900", "updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_brand.test", "custom_color", "#FF9900"),
+					resource.TestCheckResourceAttr("onelogin_brand_template.test", "subject", "updated"),
+				),
+			},
+			{
+				ResourceName:            "onelogin_brand.test",
+				ImportState:             true,
+				ImportStateId:           "name:" + name,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"logo_base64"},
+			},
+			{
+				ResourceName:      "onelogin_brand_template.test",
+				ImportState:       true,
+				ImportStateIdFunc: childImportIDFunc("onelogin_brand_template.test", "brand_id", "id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestBrandImage(t *testing.T) {
+	content, err := base64.StdEncoding.DecodeString(testBrandImage)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "logo.png")
+	require.NoError(t, os.WriteFile(file, content, 0o600))
+
+	image, err := brandImage(types.StringValue(file), types.StringNull())
+	require.NoError(t, err)
+	assert.Equal(t, testBrandImage, image)
+
+	image, err = brandImage(types.StringNull(), types.StringValue(testBrandImage))
+	require.NoError(t, err)
+	assert.Equal(t, testBrandImage, image)
+
+	// Unset images are left unchanged
+	image, err = brandImage(types.StringNull(), types.StringNull())
+	require.NoError(t, err)
+	assert.Equal(t, "", image)
+
+	_, err = brandImage(types.StringValue(filepath.Join(t.TempDir(), "missing.png")), types.StringNull())
+	assert.Error(t, err)
+
+	_, err = brandImage(types.StringNull(), types.StringValue("not base64!"))
+	assert.Error(t, err)
+}
+
+func TestBrandToState(t *testing.T) {
+	state := brandToState(&onelogin.Brand{
+		ID:          1234,
+		Name:        "test",
+		Enabled:     true,
+		CustomColor: "#3C5A99",
+		Logo:        &onelogin.BrandImage{OriginalURL: "https://example.com/logo.png"},
+	})
+
+	assert.Equal(t, int64(1234), state.ID.ValueInt64())
+	assert.Equal(t, "#3C5A99", state.CustomColor.ValueString())
+	assert.True(t, state.CustomAccentColor.IsNull())
+	assert.True(t, state.LoginInstruction.IsNull())
+	assert.Equal(t, "https://example.com/logo.png", state.LogoURL.ValueString())
+	assert.True(t, state.BackgroundURL.IsNull())
+
+	native, err := state.toNative()
+	require.NoError(t, err)
+	assert.Equal(t, "test", native.Name)
+	assert.Equal(t, "", native.Logo)
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &oneloginGroupDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginGroupDataSource{}
+	_ datasource.DataSource              = &oneloginGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginGroupsDataSource{}
+)
+
+// Groups can only be read through the api, they are managed
+// in the OneLogin admin portal.
+type oneloginGroupDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginGroupsDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginGroup struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Reference types.String `tfsdk:"reference"`
+	PolicyID  types.Int64  `tfsdk:"policy_id"`
+}
+
+func oneloginGroupTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":        types.Int64Type,
+		"name":      types.StringType,
+		"reference": types.StringType,
+		"policy_id": types.Int64Type,
+	}
+}
+
+type oneloginGroupsDataSourceModel struct {
+	Name   types.String `tfsdk:"name"`
+	Groups types.List   `tfsdk:"groups"`
+}
+
+func NewOneLoginGroupDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginGroupDataSource{
+			client: client,
+		}
+	}
+}
+
+func NewOneLoginGroupsDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginGroupsDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (d *oneloginGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+func (d *oneloginGroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func groupDataSourceAttributes() map[string]dschema.Attribute {
+	return map[string]dschema.Attribute{
+		"id": dschema.Int64Attribute{
+			Computed: true,
+		},
+		"name": dschema.StringAttribute{
+			Computed: true,
+		},
+		"reference": dschema.StringAttribute{
+			MarkdownDescription: "Reference to the group in an external directory",
+			Computed:            true,
+		},
+		"policy_id": dschema.Int64Attribute{
+			MarkdownDescription: "ID of the user policy assigned to the group, see the `onelogin_policy` data source",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *oneloginGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := groupDataSourceAttributes()
+	attributes["id"] = dschema.Int64Attribute{
+		MarkdownDescription: "ID of the group. Exactly one of `id` or `name` must be set.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["name"] = dschema.StringAttribute{
+		MarkdownDescription: "Name of the group. Exactly one of `id` or `name` must be set. Fails if more than one group has this name.",
+		Optional:            true,
+		Computed:            true,
+	}
+
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "OneLogin Group data source. Groups are managed in the OneLogin admin portal, the api is read only.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *oneloginGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "OneLogin Groups data source",
+		Attributes: map[string]dschema.Attribute{
+			"name": dschema.StringAttribute{
+				MarkdownDescription: "Only return groups with this exact name",
+				Optional:            true,
+			},
+			"groups": dschema.ListNestedAttribute{
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: groupDataSourceAttributes(),
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *oneloginGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginGroup
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ID.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"invalid group data source",
+			"exactly one of id or name must be set",
+		)
+		return
+	}
+
+	var group onelogin.Group
+	if !data.ID.IsNull() {
+		// Single groups are returned in the same envelope as the list
+		groups, err := onelogin.ListAllV1[onelogin.Group](d.client, &onelogin.Request{
+			Context: ctx,
+			Method:  onelogin.MethodGet,
+			Path:    fmt.Sprintf("%s/%v", onelogin.PathGroups, data.ID.ValueInt64()),
+		})
+		if err == nil && len(groups) != 1 {
+			err = onelogin.ErrNotFound
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read group %v, got error: %s", data.ID.ValueInt64(), err),
+			)
+			return
+		}
+		group = groups[0]
+	} else {
+		name := data.Name.ValueString()
+		found, err := listGroups(ctx, d.client, name)
+		if err == nil {
+			ids := make([]int64, len(found))
+			for i, g := range found {
+				ids[i] = g.ID
+			}
+			_, err = singleMatch("group", "name", name, ids)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read group %s, got error: %s", name, err),
+			)
+			return
+		}
+		group = found[0]
+	}
+
+	diags = resp.State.Set(ctx, groupToState(&group))
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *oneloginGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginGroupsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := listGroups(ctx, d.client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to list groups, got error: %s", err),
+		)
+		return
+	}
+
+	models := make([]*oneloginGroup, len(groups))
+	for i := range groups {
+		models[i] = groupToState(&groups[i])
+	}
+
+	data.Groups, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginGroupTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listGroups lists all groups, or only the groups with the exact name
+// if name is not empty.  Groups are filtered locally.
+func listGroups(ctx context.Context, client *onelogin.Client, name string) ([]onelogin.Group, error) {
+	groups, err := onelogin.ListAllV1[onelogin.Group](client, &onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathGroups,
+		Retry:     importListRetry,
+		RetryWait: importListRetryWait,
+		RetriableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+		},
+	})
+	if err != nil || name == "" {
+		return groups, err
+	}
+
+	found := []onelogin.Group{}
+	for _, g := range groups {
+		if g.Name == name {
+			found = append(found, g)
+		}
+	}
+	return found, nil
+}
+
+func groupToState(group *onelogin.Group) *oneloginGroup {
+	state := &oneloginGroup{
+		ID:        types.Int64Value(group.ID),
+		Name:      types.StringValue(group.Name),
+		Reference: types.StringNull(),
+		PolicyID:  types.Int64PointerValue(group.PolicyID),
+	}
+
+	// OneLogin returns an empty reference for groups created in the portal
+	if group.Reference != nil && *group.Reference != "" {
+		state.Reference = types.StringValue(*group.Reference)
+	}
+
+	return state
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *providerTestSuite) TestAccDataSourceGroups() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig + `
+					data "onelogin_groups" "all" {}
+
+					data "onelogin_group" "first" {
+						id = data.onelogin_groups.all.groups[0].id
+					}
+
+					data "onelogin_groups" "first" {
+						name = data.onelogin_group.first.name
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.onelogin_group.first", "name", "data.onelogin_groups.all", "groups.0.name"),
+					resource.TestCheckResourceAttrPair("data.onelogin_groups.first", "groups.0.id", "data.onelogin_group.first", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestGroupToState(t *testing.T) {
+	reference := ""
+	policyID := int64(42)
+
+	state := groupToState(&onelogin.Group{
+		ID:        1234,
+		Name:      "test",
+		Reference: &reference,
+		PolicyID:  &policyID,
+	})
+
+	assert.Equal(t, int64(1234), state.ID.ValueInt64())
+	assert.True(t, state.Reference.IsNull())
+	assert.Equal(t, int64(42), state.PolicyID.ValueInt64())
+
+	state = groupToState(&onelogin.Group{ID: 1, Name: "test"})
+	assert.True(t, state.Reference.IsNull())
+	assert.True(t, state.PolicyID.IsNull())
+}
@@ -3,11 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -45,6 +48,8 @@ type oneloginMapping struct {
 	Match      types.String `tfsdk:"match"`
 	Conditions types.List   `tfsdk:"conditions"`
 	Actions    types.List   `tfsdk:"actions"`
+
+	Timeouts types.Object `tfsdk:"timeouts"`
 }
 
 type oneloginMappingCondition struct {
@@ -135,6 +140,9 @@ func (d *oneloginMappingResource) Schema(ctx context.Context, req resource.Schem
 				Required: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -146,6 +154,9 @@ func (d *oneloginMappingResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutCreate, &resp.Diagnostics)
+	defer cancel()
+
 	native := state.toNativeMapping(ctx)
 
 	// Always create in the disabled state
@@ -169,8 +180,16 @@ func (d *oneloginMappingResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	state.ID = types.Int64Value(mapping.ID)
-	d.readToState(ctx, &state, &resp.State, &resp.Diagnostics)
+	// State is set even if the mapping is not consistent so that it's tracked
+	newState, diags := d.waitForMapping(ctx, &mapping)
+	resp.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+	newState.Timeouts = state.Timeouts
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (d *oneloginMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -181,6 +200,9 @@ func (d *oneloginMappingResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutRead, &resp.Diagnostics)
+	defer cancel()
+
 	d.readToState(ctx, &state, &resp.State, &resp.Diagnostics)
 }
 
@@ -192,6 +214,9 @@ func (d *oneloginMappingResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutUpdate, &resp.Diagnostics)
+	defer cancel()
+
 	id := state.ID.ValueInt64()
 
 	// Get the current enabled/disabled state from OneLogin and use that
@@ -234,7 +259,15 @@ func (d *oneloginMappingResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	d.readToState(ctx, &state, &resp.State, &resp.Diagnostics)
+	newState, diags := d.waitForMapping(ctx, &mappingResp)
+	resp.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+	newState.Timeouts = state.Timeouts
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (d *oneloginMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -245,6 +278,9 @@ func (d *oneloginMappingResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutDelete, &resp.Diagnostics)
+	defer cancel()
+
 	// Delete calls frequenty produce 5xx errors.  Retry on those errors.
 	id := state.ID.ValueInt64()
 	err := d.client.ExecRequest(&onelogin.Request{
@@ -277,7 +313,9 @@ func (d *oneloginMappingResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (d *oneloginMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	id, err := resolveImportID(ctx, "mapping", req.ID, map[string]importLookupFunc{
+		"name": mappingIDsByName(d.client),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error parsing ID for import",
@@ -287,7 +325,8 @@ func (d *oneloginMappingResource) ImportState(ctx context.Context, req resource.
 	}
 
 	state := &oneloginMapping{
-		ID: types.Int64Value(int64(id)),
+		ID:       types.Int64Value(id),
+		Timeouts: timeoutsNull(),
 	}
 
 	d.readToState(ctx, state, &resp.State, &resp.Diagnostics)
@@ -317,12 +356,99 @@ func (d *oneloginMappingResource) readToState(ctx context.Context, state *onelog
 		diags.Append(newDiags...)
 		return
 	}
+	newState.Timeouts = state.Timeouts
 
 	// Update state
 	newDiags = respState.Set(ctx, newState)
 	diags.Append(newDiags...)
 }
 
+// waitForMapping reads the mapping until it reflects the write that
+// returned expected.  Reads right after a write may return the previous
+// mapping.  The state is built from the mapping read last, which bypasses
+// the read cache, or from the written mapping if it could not be read at
+// all so that the mapping stays tracked.
+func (d *oneloginMappingResource) waitForMapping(ctx context.Context, expected *onelogin.Mapping) (*oneloginMapping, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	mapping, err := onelogin.WaitUntilConsistent(d.client, &onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodGet,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathMappings, expected.ID),
+	}, func(mapping *onelogin.Mapping) bool {
+		return mappingConsistent(mapping, expected)
+	})
+	if err != nil {
+		addConsistencyError(&diags, "mapping", expected.ID, err)
+	}
+	if mapping == nil {
+		mapping = expected
+	}
+
+	newState, newDiags := mappingToState(ctx, mapping)
+	diags.Append(newDiags...)
+	return newState, diags
+}
+
+func mappingConsistent(mapping, expected *onelogin.Mapping) bool {
+	return mapping.Name == expected.Name &&
+		mapping.Match == expected.Match &&
+		slices.Equal(mapping.Conditions, expected.Conditions) &&
+		slices.EqualFunc(mapping.Actions, expected.Actions, func(a, b onelogin.MappingAction) bool {
+			return a.Action == b.Action && slices.Equal(a.Value, b.Value)
+		})
+}
+
+// listMappings returns mappings from OneLogin.  A nil enabled returns
+// both the enabled and disabled mappings.
+func listMappings(ctx context.Context, client *onelogin.Client, enabled *bool, queryParams onelogin.QueryParams) ([]onelogin.Mapping, error) {
+	states := []bool{true, false}
+	if enabled != nil {
+		states = []bool{*enabled}
+	}
+
+	mappings := []onelogin.Mapping{}
+	for _, state := range states {
+		params := onelogin.QueryParams{}
+		for k, v := range queryParams {
+			params[k] = v
+		}
+		params["enabled"] = strconv.FormatBool(state)
+
+		var resp []onelogin.Mapping
+		err := client.ExecRequest(&onelogin.Request{
+			Context:     ctx,
+			Method:      onelogin.MethodGet,
+			Path:        onelogin.PathMappings,
+			QueryParams: params,
+			RespModel:   &resp,
+		})
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, resp...)
+	}
+
+	return mappings, nil
+}
+
+// findMappingsByName returns all mappings, enabled or disabled, with the given name.
+// Mapping names are not unique in OneLogin.
+func findMappingsByName(ctx context.Context, client *onelogin.Client, name string) ([]onelogin.Mapping, error) {
+	mappings, err := listMappings(ctx, client, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	found := []onelogin.Mapping{}
+	for _, m := range mappings {
+		if m.Name == name {
+			found = append(found, m)
+		}
+	}
+	return found, nil
+}
+
 func (state *oneloginMapping) toNativeMapping(ctx context.Context) *onelogin.Mapping {
 	native := &onelogin.Mapping{
 		ID:    state.ID.ValueInt64(),
@@ -359,6 +485,8 @@ func mappingToState(ctx context.Context, mapping *onelogin.Mapping) (*oneloginMa
 		ID:    types.Int64Value(mapping.ID),
 		Name:  types.StringValue(mapping.Name),
 		Match: types.StringValue(mapping.Match),
+
+		Timeouts: timeoutsNull(),
 	}
 
 	diags := diag.Diagnostics{}
@@ -397,3 +525,313 @@ func mappingToState(ctx context.Context, mapping *onelogin.Mapping) (*oneloginMa
 
 	return state, diags
 }
+
+// OneLogin Mapping Datasources
+
+var _ datasource.DataSource = &oneloginMappingDataSource{}
+var _ datasource.DataSourceWithConfigure = &oneloginMappingDataSource{}
+var _ datasource.DataSource = &oneloginMappingsDataSource{}
+var _ datasource.DataSourceWithConfigure = &oneloginMappingsDataSource{}
+
+type oneloginMappingDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginMappingsDataSource struct {
+	client *onelogin.Client
+}
+
+// oneloginMappingDataSourceModel is the full mapping, including the enabled
+// and position attributes that are managed by the mapping_order resource.
+type oneloginMappingDataSourceModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Match      types.String `tfsdk:"match"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Position   types.Int64  `tfsdk:"position"`
+	Conditions types.List   `tfsdk:"conditions"`
+	Actions    types.List   `tfsdk:"actions"`
+}
+
+func oneloginMappingDataSourceModelTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":         types.Int64Type,
+		"name":       types.StringType,
+		"match":      types.StringType,
+		"enabled":    types.BoolType,
+		"position":   types.Int64Type,
+		"conditions": types.ListType{ElemType: types.ObjectType{AttrTypes: oneloginMappingConditionTypes()}},
+		"actions":    types.ListType{ElemType: types.ObjectType{AttrTypes: oneloginMappingActionTypes()}},
+	}
+}
+
+type oneloginMappingsDataSourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	HasCondition types.String `tfsdk:"has_condition"`
+	HasAction    types.String `tfsdk:"has_action"`
+	Mappings     types.List   `tfsdk:"mappings"`
+}
+
+func NewOneLoginMappingDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginMappingDataSource{
+			client: client,
+		}
+	}
+}
+
+func NewOneLoginMappingsDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginMappingsDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginMappingDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mapping"
+}
+
+func (d *oneloginMappingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mappings"
+}
+
+// mappingDataSourceAttributes are the computed attributes of a mapping.
+// The id and name attributes are left to the caller.
+func mappingDataSourceAttributes() map[string]dschema.Attribute {
+	return map[string]dschema.Attribute{
+		"match": dschema.StringAttribute{
+			Computed: true,
+		},
+		"enabled": dschema.BoolAttribute{
+			Computed: true,
+		},
+		"position": dschema.Int64Attribute{
+			MarkdownDescription: "Position of the mapping in the mapping order, null if the mapping is disabled",
+			Computed:            true,
+		},
+		"conditions": dschema.ListNestedAttribute{
+			NestedObject: dschema.NestedAttributeObject{
+				Attributes: map[string]dschema.Attribute{
+					"source": dschema.StringAttribute{
+						Computed: true,
+					},
+					"operator": dschema.StringAttribute{
+						Computed: true,
+					},
+					"value": dschema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			Computed: true,
+		},
+		"actions": dschema.ListNestedAttribute{
+			NestedObject: dschema.NestedAttributeObject{
+				Attributes: map[string]dschema.Attribute{
+					"action": dschema.StringAttribute{
+						Computed: true,
+					},
+					"value": dschema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
+				},
+			},
+			Computed: true,
+		},
+	}
+}
+
+func (d *oneloginMappingDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := mappingDataSourceAttributes()
+	attributes["id"] = dschema.Int64Attribute{
+		MarkdownDescription: "ID of the mapping. Exactly one of `id` or `name` must be set.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["name"] = dschema.StringAttribute{
+		MarkdownDescription: "Name of the mapping. Exactly one of `id` or `name` must be set. Fails if more than one mapping has this name.",
+		Optional:            true,
+		Computed:            true,
+	}
+
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "OneLogin Mapping data source",
+		Attributes:          attributes,
+	}
+}
+
+func (d *oneloginMappingsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := mappingDataSourceAttributes()
+	attributes["id"] = dschema.Int64Attribute{
+		Computed: true,
+	}
+	attributes["name"] = dschema.StringAttribute{
+		Computed: true,
+	}
+
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "OneLogin Mappings data source",
+		Attributes: map[string]dschema.Attribute{
+			"name": dschema.StringAttribute{
+				MarkdownDescription: "Only return mappings with this exact name",
+				Optional:            true,
+			},
+			"enabled": dschema.BoolAttribute{
+				MarkdownDescription: "Only return enabled (true) or disabled (false) mappings. Both are returned when unset.",
+				Optional:            true,
+			},
+			"has_condition": dschema.StringAttribute{
+				MarkdownDescription: "Only return mappings with these conditions, e.g. `has_role:123456,status:1`",
+				Optional:            true,
+			},
+			"has_action": dschema.StringAttribute{
+				MarkdownDescription: "Only return mappings with these actions, e.g. `add_role:123456,set_status:1`",
+				Optional:            true,
+			},
+			"mappings": dschema.ListNestedAttribute{
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: attributes,
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *oneloginMappingDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginMappingsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginMappingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginMappingDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ID.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"invalid mapping data source",
+			"exactly one of id or name must be set",
+		)
+		return
+	}
+
+	var mapping onelogin.Mapping
+	if !data.ID.IsNull() {
+		err := d.client.ExecRequest(&onelogin.Request{
+			Context:   ctx,
+			Method:    onelogin.MethodGet,
+			Path:      fmt.Sprintf("%s/%v", onelogin.PathMappings, data.ID.ValueInt64()),
+			RespModel: &mapping,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read mapping %v, got error: %s", data.ID.ValueInt64(), err),
+			)
+			return
+		}
+	} else {
+		name := data.Name.ValueString()
+		found, err := findMappingsByName(ctx, d.client, name)
+		if err == nil {
+			ids := make([]int64, len(found))
+			for i, m := range found {
+				ids[i] = m.ID
+			}
+			_, err = singleMatch("mapping", "name", name, ids)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read mapping %s, got error: %s", name, err),
+			)
+			return
+		}
+		mapping = found[0]
+	}
+
+	newState, diags := mappingToDataSourceModel(ctx, &mapping)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *oneloginMappingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginMappingsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// has_condition and has_action are filtered by OneLogin,
+	// name is filtered locally.
+	queryParams := onelogin.QueryParams{}
+	if !data.HasCondition.IsNull() {
+		queryParams["has_condition"] = data.HasCondition.ValueString()
+	}
+	if !data.HasAction.IsNull() {
+		queryParams["has_action"] = data.HasAction.ValueString()
+	}
+
+	mappings, err := listMappings(ctx, d.client, data.Enabled.ValueBoolPointer(), queryParams)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to list mappings, got error: %s", err),
+		)
+		return
+	}
+
+	models := []*oneloginMappingDataSourceModel{}
+	for i := range mappings {
+		if !data.Name.IsNull() && mappings[i].Name != data.Name.ValueString() {
+			continue
+		}
+
+		model, diags := mappingToDataSourceModel(ctx, &mappings[i])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		models = append(models, model)
+	}
+
+	data.Mappings, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginMappingDataSourceModelTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func mappingToDataSourceModel(ctx context.Context, mapping *onelogin.Mapping) (*oneloginMappingDataSourceModel, diag.Diagnostics) {
+	state, diags := mappingToState(ctx, mapping)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &oneloginMappingDataSourceModel{
+		ID:         state.ID,
+		Name:       state.Name,
+		Match:      state.Match,
+		Enabled:    types.BoolValue(mapping.Enabled),
+		Position:   types.Int64PointerValue(mapping.Position),
+		Conditions: state.Conditions,
+		Actions:    state.Actions,
+	}, diags
+}
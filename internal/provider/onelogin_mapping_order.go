@@ -8,6 +8,9 @@ import (
 	"time"
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -15,9 +18,123 @@ import (
 )
 
 var (
-	_ resource.Resource = &oneloginMappingOrderResource{}
+	_ resource.Resource                = &oneloginMappingOrderResource{}
+	_ resource.ResourceWithImportState = &oneloginMappingOrderResource{}
 )
 
+// mappingOrderImportID is the only import id accepted by the mapping_order
+// resource.  There is a single mapping order per OneLogin instance.
+const mappingOrderImportID = "current"
+
+var _ datasource.DataSource = &oneloginMappingOrderDataSource{}
+var _ datasource.DataSourceWithConfigure = &oneloginMappingOrderDataSource{}
+
+// OneLogin Mapping Order Datasource
+
+type oneloginMappingOrderDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginMappingOrderDataSourceModel struct {
+	Enabled  types.List `tfsdk:"enabled"`
+	Disabled types.List `tfsdk:"disabled"`
+}
+
+type oneloginMappingOrderEntry struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func oneloginMappingOrderEntryTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":   types.Int64Type,
+		"name": types.StringType,
+	}
+}
+
+func NewOneLoginMappingOrderDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginMappingOrderDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginMappingOrderDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mapping_order"
+}
+
+func (d *oneloginMappingOrderDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	entry := dschema.NestedAttributeObject{
+		Attributes: map[string]dschema.Attribute{
+			"id": dschema.Int64Attribute{
+				Computed: true,
+			},
+			"name": dschema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "Current mapping order in OneLogin",
+		Attributes: map[string]dschema.Attribute{
+			"enabled": dschema.ListNestedAttribute{
+				MarkdownDescription: "Enabled mappings in the order they are evaluated",
+				NestedObject:        entry,
+				Computed:            true,
+			},
+			"disabled": dschema.ListNestedAttribute{
+				MarkdownDescription: "Disabled mappings",
+				NestedObject:        entry,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *oneloginMappingOrderDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginMappingOrderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	enabled, diags := getEnabledMappings(ctx, d.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	disabled, diags := getDisabledMappings(ctx, d.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data oneloginMappingOrderDataSourceModel
+	data.Enabled, diags = mappingOrderEntries(ctx, enabled)
+	resp.Diagnostics.Append(diags...)
+	data.Disabled, diags = mappingOrderEntries(ctx, disabled)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func mappingOrderEntries(ctx context.Context, mappings []onelogin.Mapping) (types.List, diag.Diagnostics) {
+	entries := make([]oneloginMappingOrderEntry, len(mappings))
+	for i, m := range mappings {
+		entries[i] = oneloginMappingOrderEntry{
+			ID:   types.Int64Value(m.ID),
+			Name: types.StringValue(m.Name),
+		}
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginMappingOrderEntryTypes()}, entries)
+}
+
+// OneLogin Mapping Order Resource
+
 func NewOneLoginMappingOrderResource(client *onelogin.Client) newResourceFunc {
 	return func() resource.Resource {
 		return &oneloginMappingOrderResource{
@@ -33,6 +150,8 @@ type oneloginMappingOrderResource struct {
 type oneloginMappingOrder struct {
 	Enabled  []int64 `tfsdk:"enabled"`
 	Disabled []int64 `tfsdk:"disabled"`
+
+	Timeouts types.Object `tfsdk:"timeouts"`
 }
 
 func (r *oneloginMappingOrderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -41,6 +160,8 @@ func (r *oneloginMappingOrderResource) Metadata(ctx context.Context, req resourc
 
 func (r *oneloginMappingOrderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Order of the enabled mappings and the set of disabled mappings. " +
+			"Import the live order with `terraform import onelogin_mapping_order.<name> " + mappingOrderImportID + "`.",
 		Attributes: map[string]schema.Attribute{
 			"enabled": schema.ListAttribute{
 				ElementType: types.Int64Type,
@@ -51,6 +172,9 @@ func (r *oneloginMappingOrderResource) Schema(ctx context.Context, req resource.
 				Required:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -66,6 +190,9 @@ func (r *oneloginMappingOrderResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutCreate, &resp.Diagnostics)
+	defer cancel()
+
 	diags = r.updateOrCreate(ctx, &state)
 	if diags.HasError() {
 		resp.Diagnostics = diags
@@ -88,6 +215,9 @@ func (r *oneloginMappingOrderResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutRead, &resp.Diagnostics)
+	defer cancel()
+
 	// Get enabled
 	enabled, diags := r.getEnabled(ctx)
 	if diags.HasError() {
@@ -122,6 +252,7 @@ func (r *oneloginMappingOrderResource) Read(ctx context.Context, req resource.Re
 	var newState oneloginMappingOrder
 	newState.Enabled = enabledIDs
 	newState.Disabled = state.Disabled
+	newState.Timeouts = state.Timeouts
 
 	diags = resp.State.Set(ctx, &newState)
 	if diags.HasError() {
@@ -137,6 +268,9 @@ func (r *oneloginMappingOrderResource) Update(ctx context.Context, req resource.
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutUpdate, &resp.Diagnostics)
+	defer cancel()
+
 	diags = r.updateOrCreate(ctx, &state)
 	if diags.HasError() {
 		resp.Diagnostics = diags
@@ -155,6 +289,53 @@ func (r *oneloginMappingOrderResource) Delete(ctx context.Context, req resource.
 	// Noop, nothing to delete in onelogin
 }
 
+func (r *oneloginMappingOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != mappingOrderImportID {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import mapping_order",
+			fmt.Sprintf("Could not parse ID %s: mapping_order can only be imported with the ID %q", req.ID, mappingOrderImportID),
+		)
+		return
+	}
+
+	state, diags := currentMappingOrder(ctx, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// currentMappingOrder returns the mapping order as it exists in OneLogin.
+func currentMappingOrder(ctx context.Context, client *onelogin.Client) (*oneloginMappingOrder, diag.Diagnostics) {
+	enabled, diags := getEnabledMappings(ctx, client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	disabled, newDiags := getDisabledMappings(ctx, client)
+	diags.Append(newDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	state := &oneloginMappingOrder{
+		Enabled:  make([]int64, len(enabled)),
+		Disabled: make([]int64, len(disabled)),
+		Timeouts: timeoutsNull(),
+	}
+	for i, m := range enabled {
+		state.Enabled[i] = m.ID
+	}
+	for i, m := range disabled {
+		state.Disabled[i] = m.ID
+	}
+
+	return state, diags
+}
+
 func (r *oneloginMappingOrderResource) updateOrCreate(ctx context.Context, state *oneloginMappingOrder) diag.Diagnostics {
 	// get all enabled mappings from OneLogin
 	enabled, diags := r.getEnabled(ctx)
@@ -302,11 +483,20 @@ func (r *oneloginMappingOrderResource) updateOrCreate(ctx context.Context, state
 }
 
 func (r *oneloginMappingOrderResource) getEnabled(ctx context.Context) ([]onelogin.Mapping, diag.Diagnostics) {
+	return getEnabledMappings(ctx, r.client)
+}
+
+func (r *oneloginMappingOrderResource) getDisabled(ctx context.Context) ([]onelogin.Mapping, diag.Diagnostics) {
+	return getDisabledMappings(ctx, r.client)
+}
+
+// getEnabledMappings returns all enabled mappings sorted by position
+func getEnabledMappings(ctx context.Context, client *onelogin.Client) ([]onelogin.Mapping, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 
 	// Get enabled
 	var enabled []onelogin.Mapping
-	err := r.client.ExecRequest(&onelogin.Request{
+	err := client.ExecRequest(&onelogin.Request{
 		Context:   ctx,
 		Method:    onelogin.MethodGet,
 		Path:      onelogin.PathMappings,
@@ -373,12 +563,13 @@ func (r *oneloginMappingOrderResource) getEnabled(ctx context.Context) ([]onelog
 	return enabled, nil
 }
 
-func (r *oneloginMappingOrderResource) getDisabled(ctx context.Context) ([]onelogin.Mapping, diag.Diagnostics) {
+// getDisabledMappings returns all disabled mappings in the order returned by OneLogin
+func getDisabledMappings(ctx context.Context, client *onelogin.Client) ([]onelogin.Mapping, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 
 	// Get disabled
 	var disabled []onelogin.Mapping
-	err := r.client.ExecRequest(&onelogin.Request{
+	err := client.ExecRequest(&onelogin.Request{
 		Context:   ctx,
 		Method:    onelogin.MethodGet,
 		Path:      onelogin.PathMappings,
@@ -3,12 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func (s *providerTestSuite) TestMappingOrder() {
@@ -378,3 +380,107 @@ func (s *providerTestSuite) TestAccResourceMappingOrderIntegratedDisabledOrderin
 		},
 	})
 }
+
+func (s *providerTestSuite) TestAccResourceMappingOrderImport() {
+	ctx := context.Background()
+
+	mappingOrderResource := oneloginMappingOrderResource{
+		client: s.client,
+	}
+
+	enabled, diags := mappingOrderResource.getEnabled(ctx)
+	s.Require().Nil(diags, diags.Errors())
+
+	disabled, diags := mappingOrderResource.getDisabled(ctx)
+	s.Require().Nil(diags, diags.Errors())
+
+	enabledIDs := make([]string, len(enabled))
+	for i, m := range enabled {
+		enabledIDs[i] = strconv.Itoa(int(m.ID))
+	}
+
+	disabledIDs := make([]string, len(disabled))
+	for i, m := range disabled {
+		disabledIDs[i] = strconv.Itoa(int(m.ID))
+	}
+
+	config := s.providerConfig + fmt.Sprintf(`
+		resource "onelogin_mapping_order" "test" {
+			enabled = %v
+			disabled = %v
+		}
+	`, "["+strings.Join(enabledIDs, ",")+"]", "["+strings.Join(disabledIDs, ",")+"]")
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config:        config,
+				ResourceName:  "onelogin_mapping_order.test",
+				ImportState:   true,
+				ImportStateId: "current",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					attrs := states[0].Attributes
+					if attrs["enabled.#"] != strconv.Itoa(len(enabled)) {
+						return fmt.Errorf("expected %d enabled mappings, got %s", len(enabled), attrs["enabled.#"])
+					}
+					for i, id := range enabledIDs {
+						if attrs[fmt.Sprintf("enabled.%d", i)] != id {
+							return fmt.Errorf("expected enabled.%d to be %s, got %s", i, id, attrs[fmt.Sprintf("enabled.%d", i)])
+						}
+					}
+					if attrs["disabled.#"] != strconv.Itoa(len(disabled)) {
+						return fmt.Errorf("expected %d disabled mappings, got %s", len(disabled), attrs["disabled.#"])
+					}
+					return nil
+				},
+			},
+			{
+				Config:        config,
+				ResourceName:  "onelogin_mapping_order.test",
+				ImportState:   true,
+				ImportStateId: "1234",
+				ExpectError:   regexp.MustCompile("can only be imported with the ID"),
+			},
+		},
+	})
+}
+
+func (s *providerTestSuite) TestAccDatasourceMappingOrder() {
+	ctx := context.Background()
+
+	enabled, diags := getEnabledMappings(ctx, s.client)
+	s.Require().Nil(diags, diags.Errors())
+
+	disabled, diags := getDisabledMappings(ctx, s.client)
+	s.Require().Nil(diags, diags.Errors())
+
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.onelogin_mapping_order.test", "enabled.#", fmt.Sprintf("%v", len(enabled))),
+		resource.TestCheckResourceAttr("data.onelogin_mapping_order.test", "disabled.#", fmt.Sprintf("%v", len(disabled))),
+	}
+	for i, m := range enabled {
+		checks = append(checks,
+			resource.TestCheckResourceAttr("data.onelogin_mapping_order.test", fmt.Sprintf("enabled.%d.id", i), fmt.Sprintf("%v", m.ID)),
+			resource.TestCheckResourceAttr("data.onelogin_mapping_order.test", fmt.Sprintf("enabled.%d.name", i), m.Name),
+		)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig + `
+					data "onelogin_mapping_order" "test" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+		},
+	})
+}
@@ -3,16 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"net/http"
 	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"testing"
 	"time"
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // DELETE TEST MAPPINGS
@@ -576,7 +581,7 @@ func (s *providerTestSuite) TestAccResourceMappingLoad() {
 		configUpdated.WriteString(mapping(i))
 		enabledUpdated = append(enabledUpdated, fmt.Sprintf("onelogin_mapping.terraform_test_%d.id", i))
 	}
-	rand.Shuffle(len(enabledUpdated), func(i, j int) { enabledUpdated[i], enabledUpdated[j] = enabledUpdated[j], enabledUpdated[i] })
+	s.rand.Shuffle(len(enabledUpdated), func(i, j int) { enabledUpdated[i], enabledUpdated[j] = enabledUpdated[j], enabledUpdated[i] })
 	configUpdated.WriteString(fmt.Sprintf(`
 		resource "onelogin_mapping_order" "test" {
 			enabled = [%s]
@@ -599,3 +604,201 @@ func (s *providerTestSuite) TestAccResourceMappingLoad() {
 		},
 	})
 }
+
+func TestMappingToDataSourceModel(t *testing.T) {
+	ctx := context.Background()
+	position := int64(3)
+
+	model, diags := mappingToDataSourceModel(ctx, &onelogin.Mapping{
+		ID:       1234,
+		Name:     "test_name",
+		Match:    "all",
+		Enabled:  true,
+		Position: &position,
+		Conditions: []onelogin.MappingCondition{
+			{Source: "last_login", Operator: ">", Value: "90"},
+		},
+		Actions: []onelogin.MappingAction{
+			{Action: "set_status", Value: []string{"2"}},
+		},
+	})
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, int64(1234), model.ID.ValueInt64())
+	assert.Equal(t, "test_name", model.Name.ValueString())
+	assert.True(t, model.Enabled.ValueBool())
+	assert.Equal(t, position, model.Position.ValueInt64())
+	assert.Len(t, model.Conditions.Elements(), 1)
+	assert.Len(t, model.Actions.Elements(), 1)
+
+	model, diags = mappingToDataSourceModel(ctx, &onelogin.Mapping{
+		ID:   1234,
+		Name: "test_name",
+	})
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.False(t, model.Enabled.ValueBool())
+	assert.True(t, model.Position.IsNull())
+}
+
+// Test importing a mapping by name and reading it with the mapping data sources
+func (s *providerTestSuite) TestAccMappingByName() {
+	name := "test_mapping_" + s.randString()
+
+	mappingConfig := fmt.Sprintf(`
+		resource "onelogin_mapping" "test" {
+			name = "%v"
+			match = "all"
+			conditions = [
+				{
+					source = "last_login"
+					operator = ">"
+					value = "90"
+				}
+			]
+			actions = [
+				{
+					action = "set_status"
+					value = ["2"]
+				}
+			]
+		}
+	`, name)
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig + mappingConfig,
+			},
+			{
+				Config:            s.providerConfig + mappingConfig,
+				ResourceName:      "onelogin_mapping.test",
+				ImportState:       true,
+				ImportStateId:     "name:" + name,
+				ImportStateVerify: true,
+			},
+			{
+				Config:        s.providerConfig + mappingConfig,
+				ResourceName:  "onelogin_mapping.test",
+				ImportState:   true,
+				ImportStateId: "name:" + name + "_does_not_exist",
+				ExpectError:   regexp.MustCompile("no mapping found"),
+			},
+			{
+				Config: s.providerConfig + mappingConfig + fmt.Sprintf(`
+					data "onelogin_mapping" "by_name" {
+						name = "%v"
+						depends_on = [onelogin_mapping.test]
+					}
+
+					data "onelogin_mapping" "by_id" {
+						id = onelogin_mapping.test.id
+					}
+
+					data "onelogin_mappings" "test" {
+						name = "%v"
+						enabled = false
+						depends_on = [onelogin_mapping.test]
+					}
+				`, name, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.onelogin_mapping.by_name", "id", "onelogin_mapping.test", "id"),
+					resource.TestCheckResourceAttr("data.onelogin_mapping.by_name", "enabled", "false"),
+					resource.TestCheckNoResourceAttr("data.onelogin_mapping.by_name", "position"),
+					resource.TestCheckResourceAttr("data.onelogin_mapping.by_id", "name", name),
+					resource.TestCheckResourceAttr("data.onelogin_mapping.by_id", "conditions.0.source", "last_login"),
+					resource.TestCheckResourceAttr("data.onelogin_mappings.test", "mappings.#", "1"),
+					resource.TestCheckResourceAttrPair("data.onelogin_mappings.test", "mappings.0.id", "onelogin_mapping.test", "id"),
+				),
+			},
+			{
+				// Duplicate names cannot be imported by name
+				Config: s.providerConfig + mappingConfig + fmt.Sprintf(`
+					resource "onelogin_mapping" "duplicate" {
+						name = "%v"
+						match = "all"
+						conditions = [
+							{
+								source = "last_login"
+								operator = ">"
+								value = "90"
+							}
+						]
+						actions = [
+							{
+								action = "set_status"
+								value = ["2"]
+							}
+						]
+					}
+
+					data "onelogin_mapping" "by_name" {
+						name = "%v"
+						depends_on = [onelogin_mapping.test, onelogin_mapping.duplicate]
+					}
+				`, name, name),
+				ExpectError: regexp.MustCompile("found 2 mappings"),
+			},
+		},
+	})
+}
+
+func TestWaitForMappingBypassesCache(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	authResponder, err := httpmock.NewJsonResponder(http.StatusOK, map[string]interface{}{
+		"access_token": "test_access_token",
+		"created_at":   time.Now().UTC(),
+		"expires_in":   3600,
+	})
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodPost, "https://test.onelogin.com/auth/oauth2/v2/token", authResponder)
+
+	written := onelogin.Mapping{
+		ID:    1,
+		Name:  "new",
+		Match: "all",
+		Conditions: []onelogin.MappingCondition{
+			{Source: "member_of", Operator: "contains", Value: "engineering"},
+		},
+		Actions: []onelogin.MappingAction{
+			{Action: "add_role", Value: []string{"123"}},
+		},
+	}
+	stale := written
+	stale.Name = "old"
+
+	// The cache holds the mapping as it was before the write
+	httpmock.RegisterResponder(http.MethodGet, "https://test.onelogin.com/api/2/mappings", func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("enabled") == "false" {
+			return httpmock.NewJsonResponse(http.StatusOK, []onelogin.Mapping{stale})
+		}
+		return httpmock.NewJsonResponse(http.StatusOK, []onelogin.Mapping{})
+	})
+	mappingResponder, err := httpmock.NewJsonResponder(http.StatusOK, written)
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodGet, "https://test.onelogin.com/api/2/mappings/1", mappingResponder)
+
+	client, err := onelogin.NewClient(&onelogin.ClientConfig{
+		ClientID:     "test",
+		ClientSecret: "test",
+		Subdomain:    "test",
+		ReadCache:    true,
+	})
+	require.NoError(t, err)
+
+	var cached onelogin.Mapping
+	require.NoError(t, client.ExecRequest(&onelogin.Request{
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathMappings + "/1",
+		RespModel: &cached,
+	}))
+	require.Equal(t, "old", cached.Name)
+
+	d := &oneloginMappingResource{client: client}
+	newState, diags := d.waitForMapping(context.Background(), &written)
+	assert.False(t, diags.HasError())
+	require.NotNil(t, newState)
+	assert.Equal(t, int64(1), newState.ID.ValueInt64())
+	assert.Equal(t, "new", newState.Name.ValueString())
+}
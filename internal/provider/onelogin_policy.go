@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &oneloginPolicyDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginPolicyDataSource{}
+	_ datasource.DataSource              = &oneloginPoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginPoliciesDataSource{}
+)
+
+// Policies can only be read through the api, the settings of a policy
+// are managed in the OneLogin admin portal.
+type oneloginPolicyDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginPoliciesDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginPolicy struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+}
+
+func oneloginPolicyTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":          types.Int64Type,
+		"name":        types.StringType,
+		"type":        types.StringType,
+		"description": types.StringType,
+	}
+}
+
+type oneloginPoliciesDataSourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Policies types.List   `tfsdk:"policies"`
+}
+
+func NewOneLoginPolicyDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginPolicyDataSource{
+			client: client,
+		}
+	}
+}
+
+func NewOneLoginPoliciesDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginPoliciesDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginPolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy"
+}
+
+func (d *oneloginPoliciesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policies"
+}
+
+func (d *oneloginPolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginPoliciesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func policyDataSourceAttributes() map[string]dschema.Attribute {
+	return map[string]dschema.Attribute{
+		"id": dschema.Int64Attribute{
+			Computed: true,
+		},
+		"name": dschema.StringAttribute{
+			Computed: true,
+		},
+		"type": dschema.StringAttribute{
+			MarkdownDescription: "Type of the policy, e.g. `user` or `app`",
+			Computed:            true,
+		},
+		"description": dschema.StringAttribute{
+			Computed: true,
+		},
+	}
+}
+
+func (d *oneloginPolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := policyDataSourceAttributes()
+	attributes["id"] = dschema.Int64Attribute{
+		MarkdownDescription: "ID of the policy. Exactly one of `id` or `name` must be set.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["name"] = dschema.StringAttribute{
+		MarkdownDescription: "Name of the policy. Exactly one of `id` or `name` must be set. Fails if more than one policy has this name.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["type"] = dschema.StringAttribute{
+		MarkdownDescription: "Type of the policy, e.g. `user` or `app`. Narrows the lookup by `name` when set.",
+		Optional:            true,
+		Computed:            true,
+	}
+
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "OneLogin Policy data source. Policies are managed in the OneLogin admin portal, the api is read only. " +
+			"Use the id to reference a policy from `onelogin_app.policy_id`.",
+		Attributes: attributes,
+	}
+}
+
+func (d *oneloginPoliciesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "OneLogin Policies data source",
+		Attributes: map[string]dschema.Attribute{
+			"name": dschema.StringAttribute{
+				MarkdownDescription: "Only return policies with this exact name",
+				Optional:            true,
+			},
+			"type": dschema.StringAttribute{
+				MarkdownDescription: "Only return policies of this type, e.g. `user` or `app`",
+				Optional:            true,
+			},
+			"policies": dschema.ListNestedAttribute{
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: policyDataSourceAttributes(),
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *oneloginPolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginPolicy
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ID.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"invalid policy data source",
+			"exactly one of id or name must be set",
+		)
+		return
+	}
+
+	var policy onelogin.Policy
+	if !data.ID.IsNull() {
+		// Single policies are returned in the same envelope as the list
+		policies, err := onelogin.ListAllV1[onelogin.Policy](d.client, &onelogin.Request{
+			Context: ctx,
+			Method:  onelogin.MethodGet,
+			Path:    fmt.Sprintf("%s/%v", onelogin.PathPolicies, data.ID.ValueInt64()),
+		})
+		if err == nil && len(policies) != 1 {
+			err = onelogin.ErrNotFound
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read policy %v, got error: %s", data.ID.ValueInt64(), err),
+			)
+			return
+		}
+		policy = policies[0]
+	} else {
+		name := data.Name.ValueString()
+		found, err := listPolicies(ctx, d.client, name, data.Type.ValueString())
+		if err == nil {
+			ids := make([]int64, len(found))
+			for i, p := range found {
+				ids[i] = p.ID
+			}
+			_, err = singleMatch("policy", "name", name, ids)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read policy %s, got error: %s", name, err),
+			)
+			return
+		}
+		policy = found[0]
+	}
+
+	diags = resp.State.Set(ctx, policyToState(&policy))
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *oneloginPoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginPoliciesDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := listPolicies(ctx, d.client, data.Name.ValueString(), data.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to list policies, got error: %s", err),
+		)
+		return
+	}
+
+	models := make([]*oneloginPolicy, len(policies))
+	for i := range policies {
+		models[i] = policyToState(&policies[i])
+	}
+
+	data.Policies, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginPolicyTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listPolicies lists all policies matching the name and type.  Empty
+// filters match every policy.  Policies are filtered locally.
+func listPolicies(ctx context.Context, client *onelogin.Client, name, policyType string) ([]onelogin.Policy, error) {
+	policies, err := onelogin.ListAllV1[onelogin.Policy](client, &onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathPolicies,
+		Retry:     importListRetry,
+		RetryWait: importListRetryWait,
+		RetriableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return filterPolicies(policies, name, policyType), nil
+}
+
+func filterPolicies(policies []onelogin.Policy, name, policyType string) []onelogin.Policy {
+	found := []onelogin.Policy{}
+	for _, p := range policies {
+		if name != "" && p.Name != name {
+			continue
+		}
+		if policyType != "" && p.Type != policyType {
+			continue
+		}
+		found = append(found, p)
+	}
+	return found
+}
+
+func policyToState(policy *onelogin.Policy) *oneloginPolicy {
+	state := &oneloginPolicy{
+		ID:          types.Int64Value(policy.ID),
+		Name:        types.StringValue(policy.Name),
+		Type:        types.StringNull(),
+		Description: types.StringNull(),
+	}
+
+	if policy.Type != "" {
+		state.Type = types.StringValue(policy.Type)
+	}
+	if policy.Description != "" {
+		state.Description = types.StringValue(policy.Description)
+	}
+
+	return state
+}
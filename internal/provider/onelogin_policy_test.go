@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *providerTestSuite) TestAccDataSourcePolicies() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig + `
+					data "onelogin_policies" "all" {}
+
+					data "onelogin_policy" "first" {
+						id = data.onelogin_policies.all.policies[0].id
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.onelogin_policy.first", "name", "data.onelogin_policies.all", "policies.0.name"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterPolicies(t *testing.T) {
+	policies := []onelogin.Policy{
+		{ID: 1, Name: "Default", Type: "user"},
+		{ID: 2, Name: "Default", Type: "app"},
+		{ID: 3, Name: "Contractors", Type: "app"},
+	}
+
+	assert.Len(t, filterPolicies(policies, "", ""), 3)
+	assert.Equal(t, []onelogin.Policy{policies[1], policies[2]}, filterPolicies(policies, "", "app"))
+	assert.Equal(t, []onelogin.Policy{policies[0]}, filterPolicies(policies, "Default", "user"))
+	assert.Empty(t, filterPolicies(policies, "Missing", ""))
+
+	state := policyToState(&onelogin.Policy{ID: 4, Name: "test"})
+	assert.True(t, state.Type.IsNull())
+	assert.True(t, state.Description.IsNull())
+}
@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginPrivilegeResource{}
+	_ resource.ResourceWithConfigure   = &oneloginPrivilegeResource{}
+	_ resource.ResourceWithImportState = &oneloginPrivilegeResource{}
+)
+
+// privilegeActions are the actions that can be granted by a privilege
+// https://developers.onelogin.com/api-docs/1/privileges/create-privilege
+var privilegeActions = []string{
+	"apps:List", "apps:Get", "apps:Create", "apps:Update", "apps:Delete",
+	"apps:ManageRoles", "apps:ManageUsers",
+	"users:List", "users:Get", "users:Create", "users:Update", "users:Delete",
+	"users:Unlock", "users:ResetPassword", "users:ForceLogout", "users:Invite",
+	"users:ReapplyMappings", "users:ManageRoles", "users:ManageLicense",
+	"users:ManageApps", "users:GenerateTempMfaToken",
+	"roles:List", "roles:Get", "roles:Create", "roles:Update", "roles:Delete",
+	"roles:ManageUsers", "roles:ManageApps", "roles:ManageAdmins",
+	"reports:List", "reports:Get", "reports:Create", "reports:Update",
+	"reports:Delete", "reports:Run",
+	"groups:List", "groups:Get",
+	"policies:List", "policies:Get",
+	"events:List", "events:Get",
+}
+
+type oneloginPrivilegeResource struct {
+	client *onelogin.Client
+}
+
+type oneloginPrivilege struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Statements  types.List   `tfsdk:"statements"`
+}
+
+type oneloginPrivilegeStatement struct {
+	Actions types.List `tfsdk:"actions"`
+	Scopes  types.List `tfsdk:"scopes"`
+}
+
+func oneloginPrivilegeStatementTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"actions": types.ListType{ElemType: types.StringType},
+		"scopes":  types.ListType{ElemType: types.StringType},
+	}
+}
+
+func NewOneLoginPrivilegeResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginPrivilegeResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginPrivilegeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_privilege"
+}
+
+func (r *oneloginPrivilegeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginPrivilegeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OneLogin Privilege granting delegated admin access. " +
+			"Privileges are assigned with `onelogin_privilege_roles` and `onelogin_privilege_users`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"statements": schema.ListNestedAttribute{
+				MarkdownDescription: "Statements allowing actions on resources. OneLogin only supports the `Allow` effect.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"actions": schema.ListAttribute{
+							MarkdownDescription: "Allowed actions, e.g. `users:List`",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.List{
+								listValuesOneOf(privilegeActions...),
+							},
+						},
+						"scopes": schema.ListAttribute{
+							MarkdownDescription: "Resources the actions apply to, e.g. `*` or `apps/123456`",
+							ElementType:         types.StringType,
+							Required:            true,
+						},
+					},
+				},
+				Required: true,
+			},
+		},
+	}
+}
+
+func (r *oneloginPrivilegeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginPrivilege
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created onelogin.Privilege
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      onelogin.PathPrivileges,
+		Body:      native,
+		RespModel: &created,
+	})
+	if err != nil || created.ID == "" {
+		resp.Diagnostics.AddError(
+			"Error creating privilege",
+			fmt.Sprintf("Could not create privilege %s, got error: %v", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginPrivilege
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginPrivilege
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathPrivileges, id),
+		Body:    native,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating privilege",
+			fmt.Sprintf("Could not update privilege %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginPrivilege
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathPrivileges, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "privilege to delete not found", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting privilege",
+			fmt.Sprintf("Could not delete privilege %s, got error: %s", id, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginPrivilegeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := oneloginPrivilege{
+		ID: types.StringValue(req.ID),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeResource) read(ctx context.Context, state *oneloginPrivilege, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueString()
+
+	var privilege onelogin.Privilege
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%s", onelogin.PathPrivileges, id),
+		RespModel: &privilege,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read privilege %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	newState, diags := privilegeToState(ctx, &privilege)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	diags = respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginPrivilege) toNative(ctx context.Context) (*onelogin.Privilege, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	statements := []oneloginPrivilegeStatement{}
+	if !state.Statements.IsNull() && !state.Statements.IsUnknown() {
+		diags.Append(state.Statements.ElementsAs(ctx, &statements, false)...)
+	}
+
+	privilege := &onelogin.Privilege{
+		Name:        state.Name.ValueString(),
+		Description: state.Description.ValueString(),
+		Privilege: onelogin.PrivilegePolicy{
+			Version:   onelogin.PrivilegeVersion,
+			Statement: []onelogin.PrivilegeStatement{},
+		},
+	}
+
+	for _, s := range statements {
+		statement := onelogin.PrivilegeStatement{
+			Effect: "Allow",
+			Action: []string{},
+			Scope:  []string{},
+		}
+		diags.Append(s.Actions.ElementsAs(ctx, &statement.Action, false)...)
+		diags.Append(s.Scopes.ElementsAs(ctx, &statement.Scope, false)...)
+		privilege.Privilege.Statement = append(privilege.Privilege.Statement, statement)
+	}
+
+	return privilege, diags
+}
+
+func privilegeToState(ctx context.Context, privilege *onelogin.Privilege) (*oneloginPrivilege, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	state := &oneloginPrivilege{
+		ID:          types.StringValue(privilege.ID),
+		Name:        types.StringValue(privilege.Name),
+		Description: types.StringNull(),
+	}
+
+	// OneLogin returns an empty description when none is set
+	if privilege.Description != "" {
+		state.Description = types.StringValue(privilege.Description)
+	}
+
+	statements := []oneloginPrivilegeStatement{}
+	for _, s := range privilege.Privilege.Statement {
+		actions, newDiags := types.ListValueFrom(ctx, types.StringType, nonNilStrings(s.Action))
+		diags.Append(newDiags...)
+		scopes, newDiags := types.ListValueFrom(ctx, types.StringType, nonNilStrings(s.Scope))
+		diags.Append(newDiags...)
+
+		statements = append(statements, oneloginPrivilegeStatement{
+			Actions: actions,
+			Scopes:  scopes,
+		})
+	}
+
+	var newDiags diag.Diagnostics
+	state.Statements, newDiags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginPrivilegeStatementTypes()}, statements)
+	diags.Append(newDiags...)
+
+	return state, diags
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginPrivilegeAssignmentResource{}
+	_ resource.ResourceWithConfigure   = &oneloginPrivilegeAssignmentResource{}
+	_ resource.ResourceWithImportState = &oneloginPrivilegeAssignmentResource{}
+)
+
+// oneloginPrivilegeAssignmentResource manages the roles or users a privilege
+// is assigned to.  Both resources only differ by the kind of assignee.
+type oneloginPrivilegeAssignmentResource struct {
+	client *onelogin.Client
+
+	// kind is roles or users, used in the type name and api path
+	kind string
+	// attribute holds the ids of the assignees, role_ids or user_ids
+	attribute string
+}
+
+func NewOneLoginPrivilegeRolesResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginPrivilegeAssignmentResource{
+			client:    client,
+			kind:      "roles",
+			attribute: "role_ids",
+		}
+	}
+}
+
+func NewOneLoginPrivilegeUsersResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginPrivilegeAssignmentResource{
+			client:    client,
+			kind:      "users",
+			attribute: "user_ids",
+		}
+	}
+}
+
+func (r *oneloginPrivilegeAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_privilege_" + r.kind
+}
+
+func (r *oneloginPrivilegeAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginPrivilegeAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Assigns a `onelogin_privilege` to %[1]s. "+
+			"The assignment is authoritative, %[1]s not in `%[2]s` are removed from the privilege.", r.kind, r.attribute),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Same as `privilege_id`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"privilege_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			r.attribute: schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("IDs of the %s assigned the privilege", r.kind),
+				ElementType:         types.Int64Type,
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *oneloginPrivilegeAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	privilegeID, planIDs := r.get(ctx, req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Replace any assignments made outside of terraform
+	current, err := r.list(ctx, privilegeID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read %s of privilege %s, got error: %s", r.kind, privilegeID, err),
+		)
+		return
+	}
+	currentIDs, diags := types.SetValueFrom(ctx, types.Int64Type, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, privilegeID, planIDs, currentIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, privilegeID, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	privilegeID, _ := r.get(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, privilegeID, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	privilegeID, planIDs := r.get(ctx, req.Plan, &resp.Diagnostics)
+	_, stateIDs := r.get(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, privilegeID, planIDs, stateIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, privilegeID, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	privilegeID, stateIDs := r.get(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, privilegeID, types.SetValueMust(types.Int64Type, nil), stateIDs, &resp.Diagnostics)
+}
+
+func (r *oneloginPrivilegeAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.read(ctx, req.ID, &resp.State, &resp.Diagnostics)
+}
+
+// get returns the privilege id and assigned ids from a plan or state
+func (r *oneloginPrivilegeAssignmentResource) get(ctx context.Context, state interface {
+	GetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
+}, d *diag.Diagnostics) (string, types.Set) {
+	var privilegeID types.String
+	d.Append(state.GetAttribute(ctx, path.Root("privilege_id"), &privilegeID)...)
+
+	var ids types.Set
+	d.Append(state.GetAttribute(ctx, path.Root(r.attribute), &ids)...)
+
+	return privilegeID.ValueString(), ids
+}
+
+// apply assigns and unassigns ids to move from the state to the plan
+func (r *oneloginPrivilegeAssignmentResource) apply(ctx context.Context, privilegeID string, plan, state types.Set, d *diag.Diagnostics) {
+	add, remove, diags := calculateAddRemoveUsers(ctx, plan, state)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	if len(add) > 0 {
+		var body interface{} = &onelogin.PrivilegeRoles{Roles: add}
+		if r.kind == "users" {
+			body = &onelogin.PrivilegeUsers{Users: add}
+		}
+
+		err := r.client.ExecRequest(&onelogin.Request{
+			Context: ctx,
+			Method:  onelogin.MethodPost,
+			Path:    r.path(privilegeID),
+			Body:    body,
+		})
+		if err != nil {
+			d.AddError(
+				"Error assigning privilege",
+				fmt.Sprintf("Could not assign privilege %s to %s %v, got error: %s", privilegeID, r.kind, add, err),
+			)
+			return
+		}
+	}
+
+	// The api only removes one assignee at a time
+	for _, id := range remove {
+		err := r.client.ExecRequest(&onelogin.Request{
+			Context: ctx,
+			Method:  onelogin.MethodDelete,
+			Path:    fmt.Sprintf("%s/%v", r.path(privilegeID), id),
+		})
+
+		// consider NotFound a success
+		if err == onelogin.ErrNotFound {
+			tflog.Warn(ctx, "privilege assignment to remove not found", map[string]interface{}{
+				"privilege_id": privilegeID,
+				"kind":         r.kind,
+				"id":           id,
+			})
+			continue
+		}
+
+		if err != nil {
+			d.AddError(
+				"Error removing privilege assignment",
+				fmt.Sprintf("Could not remove privilege %s from %s %v, got error: %s", privilegeID, r.kind, id, err),
+			)
+			return
+		}
+	}
+}
+
+func (r *oneloginPrivilegeAssignmentResource) read(ctx context.Context, privilegeID string, respState *tfsdk.State, d *diag.Diagnostics) {
+	ids, err := r.list(ctx, privilegeID)
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read %s of privilege %s, got error: %s", r.kind, privilegeID, err),
+		)
+		return
+	}
+
+	idSet, diags := types.SetValueFrom(ctx, types.Int64Type, ids)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	d.Append(respState.SetAttribute(ctx, path.Root("id"), privilegeID)...)
+	d.Append(respState.SetAttribute(ctx, path.Root("privilege_id"), privilegeID)...)
+	d.Append(respState.SetAttribute(ctx, path.Root(r.attribute), idSet)...)
+}
+
+func (r *oneloginPrivilegeAssignmentResource) list(ctx context.Context, privilegeID string) ([]int64, error) {
+	var roles onelogin.PrivilegeRoles
+	var users onelogin.PrivilegeUsers
+
+	var respModel interface{} = &roles
+	if r.kind == "users" {
+		respModel = &users
+	}
+
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      r.path(privilegeID),
+		RespModel: respModel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.kind == "users" {
+		return nonNilIDs(users.Users), nil
+	}
+	return nonNilIDs(roles.Roles), nil
+}
+
+func (r *oneloginPrivilegeAssignmentResource) path(privilegeID string) string {
+	return fmt.Sprintf("%s/%s/%s", onelogin.PathPrivileges, privilegeID, r.kind)
+}
+
+func nonNilIDs(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
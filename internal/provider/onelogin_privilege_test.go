@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *providerTestSuite) TestAccResourcePrivilege() {
+	name := "test_privilege_" + s.randString()
+
+	config := func(actions string, roles string) string {
+		return s.providerConfig + fmt.Sprintf(`
+			resource "onelogin_role" "first" {
+				name = "%[1]v_first"
+			}
+
+			resource "onelogin_role" "second" {
+				name = "%[1]v_second"
+			}
+
+			resource "onelogin_privilege" "test" {
+				name = "%[1]v"
+
+				statements = [
+					{
+						actions = %[2]v
+						scopes  = ["*"]
+					},
+				]
+			}
+
+			resource "onelogin_privilege_roles" "test" {
+				privilege_id = onelogin_privilege.test.id
+				role_ids     = %[3]v
+			}
+		`, name, actions, roles)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["users:List"]`, `[onelogin_role.first.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_privilege.test", "name", name),
+					resource.TestCheckResourceAttr("onelogin_privilege.test", "statements.0.actions.0", "users:List"),
+					resource.TestCheckResourceAttr("onelogin_privilege_roles.test", "role_ids.#", "1"),
+				),
+			},
+			{
+				Config: config(`["users:List", "users:Unlock"]`, `[onelogin_role.second.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_privilege.test", "statements.0.actions.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("onelogin_privilege_roles.test", "role_ids.*", "onelogin_role.second", "id"),
+					resource.TestCheckResourceAttr("onelogin_privilege_roles.test", "role_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "onelogin_privilege.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "onelogin_privilege_roles.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      config(`["users:Destroy"]`, `[]`),
+				ExpectError: regexp.MustCompile(`"users:Destroy" is not valid`),
+			},
+		},
+	})
+}
+
+func TestPrivilegeToState(t *testing.T) {
+	ctx := context.Background()
+
+	state, diags := privilegeToState(ctx, &onelogin.Privilege{
+		ID:   "5b9f4e6d-1a2b-4c3d-9e8f-7a6b5c4d3e2f",
+		Name: "test",
+		Privilege: onelogin.PrivilegePolicy{
+			Version: onelogin.PrivilegeVersion,
+			Statement: []onelogin.PrivilegeStatement{
+				{Effect: "Allow", Action: []string{"users:List", "users:Get"}, Scope: []string{"*"}},
+				{Effect: "Allow", Action: []string{"apps:List"}},
+			},
+		},
+	})
+	require.False(t, diags.HasError(), diags.Errors())
+
+	assert.True(t, state.Description.IsNull())
+	assert.Len(t, state.Statements.Elements(), 2)
+
+	native, diags := state.toNative(ctx)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, onelogin.PrivilegeVersion, native.Privilege.Version)
+	assert.Equal(t, []onelogin.PrivilegeStatement{
+		{Effect: "Allow", Action: []string{"users:List", "users:Get"}, Scope: []string{"*"}},
+		{Effect: "Allow", Action: []string{"apps:List"}, Scope: []string{}},
+	}, native.Privilege.Statement)
+}
+
+func TestListValuesOneOf(t *testing.T) {
+	ctx := context.Background()
+	v := listValuesOneOf(privilegeActions...)
+
+	validate := func(value types.List) bool {
+		resp := &validator.ListResponse{}
+		v.ValidateList(ctx, validator.ListRequest{
+			Path:        path.Root("actions"),
+			ConfigValue: value,
+		}, resp)
+		return !resp.Diagnostics.HasError()
+	}
+
+	assert.True(t, validate(types.ListValueMust(types.StringType, nil)))
+	assert.True(t, validate(types.ListNull(types.StringType)))
+	assert.True(t, validate(types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("users:List"),
+		types.StringUnknown(),
+	})))
+	assert.False(t, validate(types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("users:List"),
+		types.StringValue("users:Destroy"),
+	})))
+}
@@ -0,0 +1,460 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginRiskRuleResource{}
+	_ resource.ResourceWithConfigure   = &oneloginRiskRuleResource{}
+	_ resource.ResourceWithImportState = &oneloginRiskRuleResource{}
+
+	_ datasource.DataSource              = &oneloginRiskScoresDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginRiskScoresDataSource{}
+)
+
+var (
+	riskRuleTypes   = []string{"blacklist", "whitelist"}
+	riskRuleTargets = []string{"location.ip", "location.address.country_iso_code"}
+)
+
+// OneLogin Risk Rule Resource
+
+type oneloginRiskRuleResource struct {
+	client *onelogin.Client
+}
+
+type oneloginRiskRule struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	Target      types.String `tfsdk:"target"`
+	Filters     types.List   `tfsdk:"filters"`
+	Source      types.String `tfsdk:"source"`
+	Action      types.String `tfsdk:"action"`
+}
+
+func NewOneLoginRiskRuleResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginRiskRuleResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginRiskRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_risk_rule"
+}
+
+func (r *oneloginRiskRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginRiskRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "OneLogin Risk Rule used by adaptive authentication to allow or deny logins.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": rschema.StringAttribute{
+				Required: true,
+			},
+			"description": rschema.StringAttribute{
+				Optional: true,
+			},
+			"type": rschema.StringAttribute{
+				MarkdownDescription: "`blacklist` denies and `whitelist` allows logins matching the filters",
+				Required:            true,
+				Validators: []validator.String{
+					stringOneOf(riskRuleTypes...),
+				},
+			},
+			"target": rschema.StringAttribute{
+				MarkdownDescription: "Event attribute matched against the filters, `location.ip` or `location.address.country_iso_code`",
+				Required:            true,
+				Validators: []validator.String{
+					stringOneOf(riskRuleTargets...),
+				},
+			},
+			"filters": rschema.ListAttribute{
+				MarkdownDescription: "IP addresses, CIDR ranges or ISO country codes depending on the target",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"source": rschema.StringAttribute{
+				MarkdownDescription: "Id of a shared list of filters, e.g. `guardian-123`, used instead of `filters`",
+				Optional:            true,
+			},
+			"action": rschema.StringAttribute{
+				MarkdownDescription: "Tag added to matching events instead of allowing or denying the login",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *oneloginRiskRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginRiskRule
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created onelogin.RiskRule
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      onelogin.PathRiskRules,
+		Body:      native,
+		RespModel: &created,
+	})
+	if err != nil || created.ID == "" {
+		resp.Diagnostics.AddError(
+			"Error creating risk rule",
+			fmt.Sprintf("Could not create risk rule %s, got error: %v", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginRiskRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginRiskRule
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginRiskRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginRiskRule
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathRiskRules, id),
+		Body:    native,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating risk rule",
+			fmt.Sprintf("Could not update risk rule %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginRiskRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginRiskRule
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathRiskRules, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "risk rule to delete not found", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting risk rule",
+			fmt.Sprintf("Could not delete risk rule %s, got error: %s", id, err),
+		)
+		return
+	}
+}
+
+// ImportState accepts either the id of the rule or name:<name>
+func (r *oneloginRiskRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		var err error
+		id, err = riskRuleIDByName(ctx, r.client, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing ID for import risk rule",
+				"Could not parse ID "+req.ID+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	state := oneloginRiskRule{
+		ID:      types.StringValue(id),
+		Filters: types.ListNull(types.StringType),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginRiskRuleResource) read(ctx context.Context, state *oneloginRiskRule, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueString()
+
+	var rule onelogin.RiskRule
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%s", onelogin.PathRiskRules, id),
+		RespModel: &rule,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read risk rule %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	newState, diags := riskRuleToState(ctx, &rule)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	// Keep empty filters null if they were not configured
+	if state.Filters.IsNull() && len(rule.Filters) == 0 {
+		newState.Filters = types.ListNull(types.StringType)
+	}
+
+	diags = respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginRiskRule) toNative(ctx context.Context) (*onelogin.RiskRule, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	filters := []string{}
+	if !state.Filters.IsNull() && !state.Filters.IsUnknown() {
+		diags = state.Filters.ElementsAs(ctx, &filters, false)
+	}
+
+	return &onelogin.RiskRule{
+		Name:        state.Name.ValueString(),
+		Description: state.Description.ValueString(),
+		Type:        state.Type.ValueString(),
+		Target:      state.Target.ValueString(),
+		Filters:     filters,
+		Source:      state.Source.ValueString(),
+		Action:      state.Action.ValueString(),
+	}, diags
+}
+
+func riskRuleToState(ctx context.Context, rule *onelogin.RiskRule) (*oneloginRiskRule, diag.Diagnostics) {
+	// OneLogin returns empty strings for unset optional values
+	optionalString := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+
+	filters := rule.Filters
+	if filters == nil {
+		filters = []string{}
+	}
+	filterList, diags := types.ListValueFrom(ctx, types.StringType, filters)
+
+	return &oneloginRiskRule{
+		ID:          types.StringValue(rule.ID),
+		Name:        types.StringValue(rule.Name),
+		Description: optionalString(rule.Description),
+		Type:        types.StringValue(rule.Type),
+		Target:      types.StringValue(rule.Target),
+		Filters:     filterList,
+		Source:      optionalString(rule.Source),
+		Action:      optionalString(rule.Action),
+	}, diags
+}
+
+func riskRuleIDByName(ctx context.Context, client *onelogin.Client, name string) (string, error) {
+	var rules []onelogin.RiskRule
+	err := client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathRiskRules,
+		RespModel: &rules,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	ids := []string{}
+	for _, rule := range rules {
+		if rule.Name == name {
+			ids = append(ids, rule.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("risk rule %q: %w", name, onelogin.ErrNotFound)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found %d risk rules named %q", len(ids), name)
+	}
+}
+
+// OneLogin Risk Scores Datasource
+
+type oneloginRiskScoresDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginRiskScoresModel struct {
+	Before   types.String `tfsdk:"before"`
+	After    types.String `tfsdk:"after"`
+	Minimal  types.Int64  `tfsdk:"minimal"`
+	Low      types.Int64  `tfsdk:"low"`
+	Medium   types.Int64  `tfsdk:"medium"`
+	High     types.Int64  `tfsdk:"high"`
+	VeryHigh types.Int64  `tfsdk:"very_high"`
+	Total    types.Int64  `tfsdk:"total"`
+}
+
+func NewOneLoginRiskScoresDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginRiskScoresDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginRiskScoresDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_risk_scores"
+}
+
+func (d *oneloginRiskScoresDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginRiskScoresDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	count := func(level string) dschema.Int64Attribute {
+		return dschema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Number of events with a %s risk score", level),
+			Computed:            true,
+		}
+	}
+
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "Risk scores of recent OneLogin authentication events, counted by risk level",
+		Attributes: map[string]dschema.Attribute{
+			"before": dschema.StringAttribute{
+				MarkdownDescription: "Only count events before this date, e.g. `2024-01-31`. Defaults to now.",
+				Optional:            true,
+			},
+			"after": dschema.StringAttribute{
+				MarkdownDescription: "Only count events after this date, e.g. `2024-01-01`. Defaults to 30 days ago.",
+				Optional:            true,
+			},
+			"minimal":   count("minimal"),
+			"low":       count("low"),
+			"medium":    count("medium"),
+			"high":      count("high"),
+			"very_high": count("very high"),
+			"total": dschema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *oneloginRiskScoresDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginRiskScoresModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := onelogin.QueryParams{}
+	if !data.Before.IsNull() {
+		query["before"] = data.Before.ValueString()
+	}
+	if !data.After.IsNull() {
+		query["after"] = data.After.ValueString()
+	}
+
+	var scores onelogin.RiskScores
+	err := d.client.ExecRequest(&onelogin.Request{
+		Context:     ctx,
+		Method:      onelogin.MethodGet,
+		Path:        onelogin.PathRiskScores,
+		QueryParams: query,
+		RespModel:   &scores,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read risk scores, got error: %s", err),
+		)
+		return
+	}
+
+	data.Minimal = types.Int64Value(scores.Scores.Minimal)
+	data.Low = types.Int64Value(scores.Scores.Low)
+	data.Medium = types.Int64Value(scores.Scores.Medium)
+	data.High = types.Int64Value(scores.Scores.High)
+	data.VeryHigh = types.Int64Value(scores.Scores.VeryHigh)
+	data.Total = types.Int64Value(scores.Total)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
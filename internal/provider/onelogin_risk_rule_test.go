@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *providerTestSuite) TestAccResourceRiskRule() {
+	name := "test_risk_rule_" + s.randString()
+
+	config := func(ruleType, filters string) string {
+		return s.providerConfig + fmt.Sprintf(`
+			resource "onelogin_risk_rule" "test" {
+				name    = "%[1]v"
+				type    = "%[2]v"
+				target  = "location.ip"
+				filters = %[3]v
+			}
+
+			data "onelogin_risk_scores" "test" {}
+		`, name, ruleType, filters)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("whitelist", `["203.0.113.0/24"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_risk_rule.test", "name", name),
+					resource.TestCheckResourceAttr("onelogin_risk_rule.test", "type", "whitelist"),
+					resource.TestCheckResourceAttr("onelogin_risk_rule.test", "filters.#", "1"),
+					resource.TestCheckResourceAttrSet("data.onelogin_risk_scores.test", "total"),
+				),
+			},
+			{
+				Config: config("blacklist", `["203.0.113.0/24", "198.51.100.7"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_risk_rule.test", "type", "blacklist"),
+					resource.TestCheckResourceAttr("onelogin_risk_rule.test", "filters.1", "198.51.100.7"),
+				),
+			},
+			{
+				ResourceName:      "onelogin_risk_rule.test",
+				ImportState:       true,
+				ImportStateId:     "name:" + name,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      config("denylist", `[]`),
+				ExpectError: regexp.MustCompile(`"denylist" is not valid`),
+			},
+		},
+	})
+}
+
+func TestRiskRuleToState(t *testing.T) {
+	ctx := context.Background()
+
+	state, diags := riskRuleToState(ctx, &onelogin.RiskRule{
+		ID:      "3e2d4c1b-5a6f-4b7e-8c9d-0a1b2c3d4e5f",
+		Name:    "test",
+		Type:    "blacklist",
+		Target:  "location.address.country_iso_code",
+		Filters: []string{"KP"},
+	})
+	require.False(t, diags.HasError(), diags.Errors())
+
+	assert.Equal(t, "3e2d4c1b-5a6f-4b7e-8c9d-0a1b2c3d4e5f", state.ID.ValueString())
+	assert.True(t, state.Description.IsNull())
+	assert.True(t, state.Source.IsNull())
+	assert.True(t, state.Action.IsNull())
+	assert.Len(t, state.Filters.Elements(), 1)
+
+	native, diags := state.toNative(ctx)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, []string{"KP"}, native.Filters)
+	assert.Equal(t, "", native.Source)
+}
+
+func TestStringOneOf(t *testing.T) {
+	ctx := context.Background()
+	v := stringOneOf(riskRuleTypes...)
+
+	for value, valid := range map[types.String]bool{
+		types.StringValue("blacklist"): true,
+		types.StringValue("whitelist"): true,
+		types.StringValue("denylist"):  false,
+		types.StringValue(""):          false,
+		types.StringNull():             true,
+		types.StringUnknown():          true,
+	} {
+		resp := &validator.StringResponse{}
+		v.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("type"),
+			ConfigValue: value,
+		}, resp)
+		assert.Equal(t, !valid, resp.Diagnostics.HasError(), value.String())
+	}
+}
@@ -3,9 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/ghaggin/terraform-provider-onelogin/internal/util"
@@ -28,22 +26,13 @@ var (
 func NewOneLoginRoleResource(client *onelogin.Client) newResourceFunc {
 	return func() resource.Resource {
 		return &oneloginRoleResource{
-			client:            client,
-			delayReadRoles:    make(map[int64]time.Time),
-			delayReadRolesMu:  sync.Mutex{},
-			delayReadDuration: time.Millisecond * 2000,
+			client: client,
 		}
 	}
 }
 
 type oneloginRoleResource struct {
 	client *onelogin.Client
-
-	// Delay read after write variables, only enabled
-	// for testing.
-	delayReadRoles    map[int64]time.Time
-	delayReadRolesMu  sync.Mutex
-	delayReadDuration time.Duration
 }
 
 type oneloginRole struct {
@@ -54,8 +43,11 @@ type oneloginRole struct {
 	Apps   types.Set `tfsdk:"apps"`
 	Users  types.Set `tfsdk:"users"`
 
-	// LastUpdated attribute local to terraform object
-	LastUpdated types.String `tfsdk:"last_updated"`
+	// LastUpdated and AdoptExisting attributes local to terraform object
+	LastUpdated   types.String `tfsdk:"last_updated"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+
+	Timeouts types.Object `tfsdk:"timeouts"`
 }
 
 func (d *oneloginRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -95,6 +87,10 @@ func (d *oneloginRoleResource) Schema(ctx context.Context, req resource.SchemaRe
 				MarkdownDescription: "Timestamp of the last time this role was updated",
 				Computed:            true,
 			},
+			"adopt_existing": adoptExistingAttribute("role"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
@@ -108,6 +104,33 @@ func (d *oneloginRoleResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutCreate, &resp.Diagnostics)
+	defer cancel()
+
+	// The existing roles with the name are never mistaken for the role
+	// created by a failed create, they are only adopted if configured.
+	name := state.Name.ValueString()
+	reconciler := newCreateReconciler("role", name, roleIDsByName(d.client))
+	if state.AdoptExisting.ValueBool() {
+		reconciler.collect(ctx)
+		id, err := reconciler.adoptable()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error adopting role",
+				"Could not adopt existing role: "+err.Error(),
+			)
+			return
+		}
+		if id != 0 {
+			tflog.Info(ctx, "adopting existing role", map[string]interface{}{
+				"name": name,
+				"id":   id,
+			})
+			d.adopt(ctx, id, &state, resp)
+			return
+		}
+	}
+
 	newRole, diags := state.toNative(ctx)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -122,6 +145,28 @@ func (d *oneloginRoleResource) Create(ctx context.Context, req resource.CreateRe
 		Body:      newRole,
 		RespModel: &role,
 	})
+	if err != nil && onelogin.MayHaveSucceeded(err) {
+		// OneLogin may finish the create after the request failed,
+		// adopt the role so that the next apply doesn't create a duplicate.
+		reconcileCtx, reconcileCancel := reconcileContext(ctx)
+		defer reconcileCancel()
+
+		id, lookupErr := reconciler.created(reconcileCtx)
+		if lookupErr != nil {
+			resp.Diagnostics.AddError(
+				"Error creating role",
+				orphanDetail("role", name, err, lookupErr),
+			)
+			return
+		}
+		tflog.Warn(reconcileCtx, "adopting role created by failed request", map[string]interface{}{
+			"name":  name,
+			"id":    id,
+			"error": err.Error(),
+		})
+		d.adopt(reconcileCtx, id, &state, resp)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating role",
@@ -130,14 +175,16 @@ func (d *oneloginRoleResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	d.delayReads(role.ID)
-
-	newState, diags := d.read(ctx, role.ID, state.Users)
-	if diags.HasError() {
+	// State is set even if the role is not consistent so that it's tracked
+	newState, diags := d.waitForRole(ctx, role.ID, newRole, nil, state.Users)
+	resp.Diagnostics.Append(diags...)
+	if newState == nil {
 		return
 	}
 
 	newState.LastUpdated = types.StringValue(util.GetTimestampString())
+	newState.AdoptExisting = state.AdoptExisting
+	newState.Timeouts = state.Timeouts
 
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
@@ -146,6 +193,28 @@ func (d *oneloginRoleResource) Create(ctx context.Context, req resource.CreateRe
 	}
 }
 
+// adopt takes over the existing role with the id and updates it to
+// match the plan.  Users of the role that are not in the plan are kept,
+// they are not tracked by terraform.
+func (d *oneloginRoleResource) adopt(ctx context.Context, id int64, plan *oneloginRole, resp *resource.CreateResponse) {
+	plan.ID = types.Int64Value(id)
+
+	current, diags := d.read(ctx, id, plan.Users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, diags := d.update(ctx, plan, current.Users)
+	resp.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
 func (d *oneloginRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state oneloginRole
 	diags := req.State.Get(ctx, &state)
@@ -154,6 +223,9 @@ func (d *oneloginRoleResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutRead, &resp.Diagnostics)
+	defer cancel()
+
 	newState, diags := d.read(ctx, state.ID.ValueInt64(), state.Users)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -161,6 +233,8 @@ func (d *oneloginRoleResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	newState.LastUpdated = state.LastUpdated
+	newState.AdoptExisting = state.AdoptExisting
+	newState.Timeouts = state.Timeouts
 
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
@@ -177,16 +251,42 @@ func (d *oneloginRoleResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	body, diags := plan.toNative(ctx)
+	var state oneloginRole
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, plan.Timeouts, timeoutUpdate, &resp.Diagnostics)
+	defer cancel()
+
+	newState, diags := d.update(ctx, &plan, state.Users)
+	resp.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// update updates the role to match the plan.  Users are added and
+// removed based on the difference between the plan and the tracked users.
+func (d *oneloginRoleResource) update(ctx context.Context, plan *oneloginRole, trackedUsers types.Set) (*oneloginRole, diag.Diagnostics) {
+	body, diags := plan.toNative(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+
 	body.ID = 0 // zero out id to omit from the json body
 
 	// Omit users from the full update.
 	// Update users individually based on add/remove from plan
+	expected := *body
 	body.Users = nil
 
 	var role onelogin.Role
@@ -199,24 +299,18 @@ func (d *oneloginRoleResource) Update(ctx context.Context, req resource.UpdateRe
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error updating role",
 			"Could not update role: "+err.Error(),
 		)
-		return
+		return nil, diags
 	}
 
 	// Calculate the added and removed users
-	var state oneloginRole
-	diags = req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	addUsers, removeUsers, diags := calculateAddRemoveUsers(ctx, plan.Users, state.Users)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	addUsers, removeUsers, newDiags := calculateAddRemoveUsers(ctx, plan.Users, trackedUsers)
+	diags.Append(newDiags...)
+	if diags.HasError() {
+		return nil, diags
 	}
 
 	// Add users
@@ -232,18 +326,18 @@ func (d *oneloginRoleResource) Update(ctx context.Context, req resource.UpdateRe
 			RespModel: &addUserResp,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error adding users to role",
 				"Could not add users to role: "+err.Error(),
 			)
-			return
+			return nil, diags
 		}
 		if len(addUserResp) != len(addUsers) {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error adding users to role",
 				fmt.Sprintf("Could not add all users to role\nadddUserResp: %v\naddUsers: %v", addUserResp, addUsers),
 			)
-			return
+			return nil, diags
 		}
 	}
 
@@ -256,32 +350,26 @@ func (d *oneloginRoleResource) Update(ctx context.Context, req resource.UpdateRe
 			Body:    removeUsers,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error removing users from role",
 				"Could not remove users from role: "+err.Error(),
 			)
-			return
+			return nil, diags
 		}
 	}
 
-	d.delayReads(role.ID)
-
-	// I think that add and delete may return prior to the transaction being fully committed.
-	// In the case the transaction is not fully committed, the read will produce inconsistent results.
-	// We will assume that user updates are eventually consistent and update the state to their expected value.
-	newState, diags := d.read(ctx, role.ID, plan.Users)
-	if diags.HasError() {
-		return
+	// Add and delete may return before the users are visible in reads of the role
+	newState, newDiags := d.waitForRole(ctx, plan.ID.ValueInt64(), &expected, removeUsers, plan.Users)
+	diags.Append(newDiags...)
+	if newState == nil {
+		return nil, diags
 	}
-	newState.Users = plan.Users
 
 	newState.LastUpdated = types.StringValue(util.GetTimestampString())
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.Timeouts = plan.Timeouts
 
-	diags = resp.State.Set(ctx, newState)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	return newState, diags
 }
 
 func (d *oneloginRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -292,6 +380,9 @@ func (d *oneloginRoleResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	ctx, cancel := timeoutContext(ctx, state.Timeouts, timeoutDelete, &resp.Diagnostics)
+	defer cancel()
+
 	// queryParams is inexplicably unused
 	err := d.client.ExecRequest(&onelogin.Request{
 		Context: ctx,
@@ -318,13 +409,15 @@ func (d *oneloginRoleResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (d *oneloginRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	id, err := resolveImportID(ctx, "role", req.ID, map[string]importLookupFunc{
+		"name": roleIDsByName(d.client),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Error parsing ID for import role", "Could not parse ID "+req.ID+": "+err.Error())
 		return
 	}
 
-	state, diags := d.read(ctx, int64(id), types.SetNull(types.Int64Type))
+	state, diags := d.read(ctx, id, types.SetNull(types.Int64Type))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -340,17 +433,9 @@ func (d *oneloginRoleResource) ImportState(ctx context.Context, req resource.Imp
 func (d *oneloginRoleResource) read(ctx context.Context, id int64, trackedUsers types.Set) (*oneloginRole, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 
-	// delay read after write
-	// onelogin is eventually consistent
-	err := d.maybeDelayRead(ctx, id)
-	if err != nil {
-		diags.AddError("maybeDelayRead returned error", err.Error())
-		return nil, diags
-	}
-
 	// Read requests frequently produce 5xx errors.  Retry on these errors.
 	var role onelogin.Role
-	err = d.client.ExecRequest(&onelogin.Request{
+	err := d.client.ExecRequest(&onelogin.Request{
 		Context:   ctx,
 		Method:    onelogin.MethodGet,
 		Path:      fmt.Sprintf("%s/%v", onelogin.PathRoles, id),
@@ -408,6 +493,9 @@ func roleToState(ctx context.Context, role *onelogin.Role, trackedUsers types.Se
 		Admins: types.SetNull(types.Int64Type),
 		Apps:   types.SetNull(types.Int64Type),
 		Users:  types.SetNull(types.Int64Type),
+
+		AdoptExisting: types.BoolNull(),
+		Timeouts:      timeoutsNull(),
 	}
 
 	admins, newDiags := types.SetValueFrom(ctx, types.Int64Type, role.Admins)
@@ -492,45 +580,53 @@ func calculateAddRemoveUsers(ctx context.Context, plan, state types.Set) ([]int6
 	return add, remove, diags
 }
 
-func (d *oneloginRoleResource) maybeDelayRead(ctx context.Context, id int64) error {
-	// only perform this action for testing
-	// read after write is only a problem for a small number of roles
-	if os.Getenv("TF_ACC") == "" {
-		return nil
-	}
-
-	for {
-		d.delayReadRolesMu.Lock()
-
-		deadline, ok := d.delayReadRoles[id]
-		if !ok {
-			d.delayReadRolesMu.Unlock()
-			return nil
-		}
+// waitForRole reads the role until it reflects a write of expected.  Users
+// are tracked individually, the expected users must be assigned and the
+// removed users must not be.  The role read last is returned with an error
+// if the role is not consistent in time, or the written role if it could
+// not be read at all so that the role stays tracked.
+func (d *oneloginRoleResource) waitForRole(ctx context.Context, id int64, expected *onelogin.Role, removedUsers []int64, trackedUsers types.Set) (*oneloginRole, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
 
-		if time.Now().After(deadline) {
-			delete(d.delayReadRoles, id)
-			d.delayReadRolesMu.Unlock()
-			return nil
-		}
+	role, err := onelogin.WaitUntilConsistent(d.client, &onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodGet,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathRoles, id),
 
-		d.delayReadRolesMu.Unlock()
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(time.Until(deadline)):
-		}
+		Retry:                3,
+		RetryWait:            time.Second,
+		RetriableStatusCodes: []int{429, 500, 502, 504},
+	}, func(role *onelogin.Role) bool {
+		return roleConsistent(role, expected, removedUsers)
+	})
+	if err != nil {
+		addConsistencyError(&diags, "role", id, err)
+	}
+	if role == nil {
+		written := *expected
+		written.ID = id
+		role = &written
 	}
+
+	newState, newDiags := roleToState(ctx, role, trackedUsers)
+	diags.Append(newDiags...)
+	return newState, diags
 }
 
-func (d *oneloginRoleResource) delayReads(id int64) {
-	// only perform this action for testing
-	// read after write is only a problem for a small number of roles
-	if os.Getenv("TF_ACC") == "" {
-		return
+// roleConsistent compares only the fields that were written.  Empty admins
+// and apps are omitted from the request and are left as they are.
+func roleConsistent(role, expected *onelogin.Role, removedUsers []int64) bool {
+	if role.Name != expected.Name ||
+		(len(expected.Admins) > 0 && !sameIDs(role.Admins, expected.Admins)) ||
+		(len(expected.Apps) > 0 && !sameIDs(role.Apps, expected.Apps)) ||
+		!containsIDs(role.Users, expected.Users) {
+		return false
 	}
 
-	d.delayReadRolesMu.Lock()
-	d.delayReadRoles[id] = time.Now().Add(d.delayReadDuration)
-	d.delayReadRolesMu.Unlock()
+	for _, id := range removedUsers {
+		if containsIDs(role.Users, []int64{id}) {
+			return false
+		}
+	}
+	return true
 }
@@ -4,18 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
 	"sort"
+	"testing"
+	"time"
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Isolated Role Test
 // This tests functionality that is not dependent on other resources
 func (s *providerTestSuite) TestAccOneloginRoleIsolated() {
-	roleName := "test_role_" + acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	roleName := "test_role_" + s.randString()
 
 	resource.Test(s.T(), resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -289,6 +296,72 @@ func (s *providerTestSuite) TestAccOneloginRoleImported() {
 	})
 }
 
+func (s *providerTestSuite) TestAccOneloginRoleImportByName() {
+	roleName := "test_role_" + s.randString()
+	roleConfig := fmt.Sprintf(`
+		resource "onelogin_role" "test_role" {
+			name = "%v"
+		}
+	`, roleName)
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig + roleConfig,
+			},
+			{
+				Config:                  s.providerConfig + roleConfig,
+				ResourceName:            "onelogin_role.test_role",
+				ImportState:             true,
+				ImportStateId:           "name:" + roleName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				Config:        s.providerConfig + roleConfig,
+				ResourceName:  "onelogin_role.test_role",
+				ImportState:   true,
+				ImportStateId: "name:" + roleName + "_does_not_exist",
+				ExpectError:   regexp.MustCompile("no role found"),
+			},
+		},
+	})
+}
+
+func (s *providerTestSuite) TestAccOneloginRoleAdoptExisting() {
+	roleName := "test_role_" + s.randString()
+	roleConfig := fmt.Sprintf(`
+		resource "onelogin_role" "test_role" {
+			name = "%v"
+		}
+	`, roleName)
+	adoptConfig := fmt.Sprintf(`
+		resource "onelogin_role" "adopted_role" {
+			name           = "%v"
+			adopt_existing = true
+
+			depends_on = [onelogin_role.test_role]
+		}
+	`, roleName)
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig + roleConfig,
+			},
+			{
+				Config: s.providerConfig + roleConfig + adoptConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("onelogin_role.adopted_role", "id", "onelogin_role.test_role", "id"),
+					resource.TestCheckResourceAttr("onelogin_role.adopted_role", "adopt_existing", "true"),
+				),
+			},
+		},
+	})
+}
+
 func (s *providerTestSuite) TestRoleOrder() {
 	var apps []onelogin.Application
 	err := s.client.ExecRequestPaged(&onelogin.Request{
@@ -340,3 +413,65 @@ func (s *providerTestSuite) TestRoleOrder() {
 		},
 	})
 }
+
+func TestRoleConsistent(t *testing.T) {
+	expected := &onelogin.Role{
+		Name:   "role",
+		Admins: []int64{1},
+		Apps:   []int64{2, 3},
+		Users:  []int64{4},
+	}
+
+	tests := []struct {
+		name     string
+		role     onelogin.Role
+		removed  []int64
+		expected bool
+	}{
+		{name: "match", role: onelogin.Role{Name: "role", Admins: []int64{1}, Apps: []int64{3, 2}, Users: []int64{4}}, expected: true},
+		{name: "untracked users", role: onelogin.Role{Name: "role", Admins: []int64{1}, Apps: []int64{2, 3}, Users: []int64{4, 5}}, expected: true},
+		{name: "stale name", role: onelogin.Role{Name: "old", Admins: []int64{1}, Apps: []int64{2, 3}, Users: []int64{4}}},
+		{name: "missing app", role: onelogin.Role{Name: "role", Admins: []int64{1}, Apps: []int64{2}, Users: []int64{4}}},
+		{name: "missing user", role: onelogin.Role{Name: "role", Admins: []int64{1}, Apps: []int64{2, 3}}},
+		{name: "removed user", role: onelogin.Role{Name: "role", Admins: []int64{1}, Apps: []int64{2, 3}, Users: []int64{4, 5}}, removed: []int64{5}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, roleConsistent(&test.role, expected, test.removed), test.name)
+	}
+
+	// Empty admins and apps are not written
+	unset := &onelogin.Role{Name: "role", Admins: []int64{}, Apps: []int64{}}
+	assert.True(t, roleConsistent(&onelogin.Role{Name: "role", Admins: []int64{1}, Apps: []int64{2}}, unset, nil))
+	assert.False(t, roleConsistent(&onelogin.Role{Name: "old"}, unset, nil))
+}
+
+func TestWaitForRoleUnreadable(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	authResponder, err := httpmock.NewJsonResponder(http.StatusOK, map[string]interface{}{
+		"access_token": "test_access_token",
+		"created_at":   time.Now().UTC(),
+		"expires_in":   3600,
+	})
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodPost, "https://test.onelogin.com/auth/oauth2/v2/token", authResponder)
+	httpmock.RegisterResponder(http.MethodGet, "https://test.onelogin.com/api/2/roles/1", httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	client, err := onelogin.NewClient(&onelogin.ClientConfig{
+		ClientID:           "test",
+		ClientSecret:       "test",
+		Subdomain:          "test",
+		ConsistencyTimeout: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	// The written role is tracked if the new role is never readable
+	d := &oneloginRoleResource{client: client}
+	newState, diags := d.waitForRole(context.Background(), 1, &onelogin.Role{Name: "role", Apps: []int64{2}}, nil, types.SetNull(types.Int64Type))
+	assert.True(t, diags.HasError())
+	require.NotNil(t, newState)
+	assert.Equal(t, int64(1), newState.ID.ValueInt64())
+	assert.Equal(t, "role", newState.Name.ValueString())
+}
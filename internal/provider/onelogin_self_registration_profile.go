@@ -0,0 +1,702 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &oneloginSelfRegistrationProfileResource{}
+	_ resource.ResourceWithConfigure      = &oneloginSelfRegistrationProfileResource{}
+	_ resource.ResourceWithImportState    = &oneloginSelfRegistrationProfileResource{}
+	_ resource.ResourceWithValidateConfig = &oneloginSelfRegistrationProfileResource{}
+)
+
+type oneloginSelfRegistrationProfileResource struct {
+	client *onelogin.Client
+}
+
+type oneloginSelfRegistrationProfile struct {
+	ID                    types.Int64  `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	URL                   types.String `tfsdk:"url"`
+	Enabled               types.Bool   `tfsdk:"enabled"`
+	Title                 types.String `tfsdk:"title"`
+	Helptext              types.String `tfsdk:"helptext"`
+	ThankyouMessage       types.String `tfsdk:"thankyou_message"`
+	Moderated             types.Bool   `tfsdk:"moderated"`
+	DefaultRoleID         types.Int64  `tfsdk:"default_role_id"`
+	DefaultGroupID        types.Int64  `tfsdk:"default_group_id"`
+	EmailVerificationType types.String `tfsdk:"email_verification_type"`
+	AllowedDomains        types.List   `tfsdk:"allowed_domains"`
+	BlockedDomains        types.List   `tfsdk:"blocked_domains"`
+	Fields                types.List   `tfsdk:"fields"`
+}
+
+type oneloginSelfRegistrationProfileField struct {
+	ID                types.Int64  `tfsdk:"id"`
+	CustomAttributeID types.Int64  `tfsdk:"custom_attribute_id"`
+	Name              types.String `tfsdk:"name"`
+}
+
+func oneloginSelfRegistrationProfileFieldTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":                  types.Int64Type,
+		"custom_attribute_id": types.Int64Type,
+		"name":                types.StringType,
+	}
+}
+
+func NewOneLoginSelfRegistrationProfileResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginSelfRegistrationProfileResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginSelfRegistrationProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_self_registration_profile"
+}
+
+func (r *oneloginSelfRegistrationProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginSelfRegistrationProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OneLogin Self Registration Profile used to let external users create their own accounts.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Path of the registration page, `https://<subdomain>.onelogin.com/self_registration/<url>`",
+				Required:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Optional: true,
+			},
+			"helptext": schema.StringAttribute{
+				Optional: true,
+			},
+			"thankyou_message": schema.StringAttribute{
+				Optional: true,
+			},
+			"moderated": schema.BoolAttribute{
+				MarkdownDescription: "New users must be approved by an admin before they can sign in",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_role_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the `onelogin_role` assigned to new users",
+				Optional:            true,
+			},
+			"default_group_id": schema.Int64Attribute{
+				Optional: true,
+			},
+			"email_verification_type": schema.StringAttribute{
+				MarkdownDescription: "How new users verify their email, `Email MagicLink` or `OTP Code`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allowed_domains": schema.ListAttribute{
+				MarkdownDescription: "Only allow registration with email addresses in these domains. Must not be empty. Conflicts with `blocked_domains`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"blocked_domains": schema.ListAttribute{
+				MarkdownDescription: "Deny registration with email addresses in these domains. Must not be empty. Conflicts with `allowed_domains`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"fields": schema.ListNestedAttribute{
+				MarkdownDescription: "Custom user fields shown on the registration page",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.Int64{
+								fieldStateModifier{},
+							},
+						},
+						"custom_attribute_id": schema.Int64Attribute{
+							Required: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								fieldStateModifier{},
+							},
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *oneloginSelfRegistrationProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config oneloginSelfRegistrationProfile
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.AllowedDomains.IsNull() && !config.BlockedDomains.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("blocked_domains"),
+			"Conflicting domain lists",
+			"Only one of allowed_domains and blocked_domains can be set",
+		)
+	}
+
+	// Empty lists read back as null, omit the attribute instead
+	for _, domains := range []struct {
+		name string
+		list types.List
+	}{
+		{"allowed_domains", config.AllowedDomains},
+		{"blocked_domains", config.BlockedDomains},
+	} {
+		if !domains.list.IsNull() && !domains.list.IsUnknown() && len(domains.list.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(domains.name),
+				"Empty domain list",
+				domains.name+" must contain at least one domain, remove the attribute to allow all domains",
+			)
+		}
+	}
+}
+
+func (r *oneloginSelfRegistrationProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginSelfRegistrationProfile
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created onelogin.SelfRegistrationProfile
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      onelogin.PathSelfRegistrationProfiles,
+		Body:      native,
+		RespModel: &created,
+	})
+	if err != nil || created.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error creating self registration profile",
+			fmt.Sprintf("Could not create self registration profile %s, got error: %v", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(created.ID)
+
+	// Save the profile before adding fields so it is not orphaned if adding
+	// a field fails
+	diags = resp.State.SetAttribute(ctx, path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+
+	r.syncFields(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSelfRegistrationProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginSelfRegistrationProfile
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSelfRegistrationProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginSelfRegistrationProfile
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathSelfRegistrationProfiles, id),
+		Body:    native,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating self registration profile",
+			fmt.Sprintf("Could not update self registration profile %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	r.syncFields(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSelfRegistrationProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginSelfRegistrationProfile
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathSelfRegistrationProfiles, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "self registration profile to delete not found", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting self registration profile",
+			fmt.Sprintf("Could not delete self registration profile %v, got error: %s", id, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginSelfRegistrationProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(ctx, "self registration profile", req.ID, map[string]importLookupFunc{
+		"name": selfRegistrationProfileIDsByName(r.client),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import self registration profile",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginSelfRegistrationProfile{
+		ID:             types.Int64Value(id),
+		AllowedDomains: types.ListNull(types.StringType),
+		BlockedDomains: types.ListNull(types.StringType),
+		Fields:         types.ListNull(types.ObjectType{AttrTypes: oneloginSelfRegistrationProfileFieldTypes()}),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSelfRegistrationProfileResource) read(ctx context.Context, state *oneloginSelfRegistrationProfile, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueInt64()
+
+	var profile onelogin.SelfRegistrationProfile
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v", onelogin.PathSelfRegistrationProfiles, id),
+		RespModel: &profile,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read self registration profile %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	// Keep the configured order of fields, OneLogin returns them in the
+	// order they were added
+	if !state.Fields.IsNull() && !state.Fields.IsUnknown() {
+		fields := []oneloginSelfRegistrationProfileField{}
+		d.Append(state.Fields.ElementsAs(ctx, &fields, false)...)
+		if d.HasError() {
+			return
+		}
+		order := map[int64]int{}
+		for i, field := range fields {
+			order[field.CustomAttributeID.ValueInt64()] = i
+		}
+		sort.SliceStable(profile.Fields, func(i, j int) bool {
+			oi, ok := order[profile.Fields[i].CustomAttributeID]
+			if !ok {
+				oi = len(order)
+			}
+			oj, ok := order[profile.Fields[j].CustomAttributeID]
+			if !ok {
+				oj = len(order)
+			}
+			return oi < oj
+		})
+	}
+
+	newState, diags := selfRegistrationProfileToState(ctx, &profile)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	// Keep an empty list of fields null if it was not configured
+	if state.Fields.IsNull() && len(profile.Fields) == 0 {
+		newState.Fields = types.ListNull(types.ObjectType{AttrTypes: oneloginSelfRegistrationProfileFieldTypes()})
+	}
+
+	diags = respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+// syncFields adds and removes fields so the profile has exactly the
+// configured custom attributes.  Fields are added in the configured order.
+func (r *oneloginSelfRegistrationProfileResource) syncFields(ctx context.Context, state *oneloginSelfRegistrationProfile, d *diag.Diagnostics) {
+	id := state.ID.ValueInt64()
+
+	var profile onelogin.SelfRegistrationProfile
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v", onelogin.PathSelfRegistrationProfiles, id),
+		RespModel: &profile,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read fields of self registration profile %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	desired := []int64{}
+	if !state.Fields.IsNull() && !state.Fields.IsUnknown() {
+		fields := []oneloginSelfRegistrationProfileField{}
+		d.Append(state.Fields.ElementsAs(ctx, &fields, false)...)
+		if d.HasError() {
+			return
+		}
+		for _, field := range fields {
+			desired = append(desired, field.CustomAttributeID.ValueInt64())
+		}
+	}
+
+	toAdd, toRemove := diffSelfRegistrationProfileFields(desired, profile.Fields)
+
+	for _, field := range toRemove {
+		err := r.client.ExecRequest(&onelogin.Request{
+			Context: ctx,
+			Method:  onelogin.MethodDelete,
+			Path:    fmt.Sprintf("%s/%v", selfRegistrationProfileFieldsPath(id), field.ID),
+		})
+		if err != nil && err != onelogin.ErrNotFound {
+			d.AddError(
+				"Error updating self registration profile fields",
+				fmt.Sprintf("Could not remove field %s from self registration profile %v, got error: %s", field.Name, id, err),
+			)
+			return
+		}
+	}
+
+	for _, customAttributeID := range toAdd {
+		err := r.client.ExecRequest(&onelogin.Request{
+			Context: ctx,
+			Method:  onelogin.MethodPost,
+			Path:    selfRegistrationProfileFieldsPath(id),
+			Body: &onelogin.SelfRegistrationProfileField{
+				CustomAttributeID: customAttributeID,
+			},
+		})
+		if err != nil {
+			d.AddError(
+				"Error updating self registration profile fields",
+				fmt.Sprintf("Could not add custom attribute %v to self registration profile %v, got error: %s", customAttributeID, id, err),
+			)
+			return
+		}
+	}
+}
+
+// diffSelfRegistrationProfileFields returns the custom attributes to add and
+// the existing fields to remove
+func diffSelfRegistrationProfileFields(desired []int64, existing []onelogin.SelfRegistrationProfileField) ([]int64, []onelogin.SelfRegistrationProfileField) {
+	wanted := map[int64]bool{}
+	for _, customAttributeID := range desired {
+		wanted[customAttributeID] = true
+	}
+
+	present := map[int64]bool{}
+	toRemove := []onelogin.SelfRegistrationProfileField{}
+	for _, field := range existing {
+		present[field.CustomAttributeID] = true
+		if !wanted[field.CustomAttributeID] {
+			toRemove = append(toRemove, field)
+		}
+	}
+
+	toAdd := []int64{}
+	for _, customAttributeID := range desired {
+		if !present[customAttributeID] {
+			toAdd = append(toAdd, customAttributeID)
+			present[customAttributeID] = true
+		}
+	}
+
+	return toAdd, toRemove
+}
+
+func (state *oneloginSelfRegistrationProfile) toNative(ctx context.Context) (*onelogin.SelfRegistrationProfile, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	allowed := []string{}
+	if !state.AllowedDomains.IsNull() && !state.AllowedDomains.IsUnknown() {
+		diags.Append(state.AllowedDomains.ElementsAs(ctx, &allowed, false)...)
+	}
+
+	blocked := []string{}
+	if !state.BlockedDomains.IsNull() && !state.BlockedDomains.IsUnknown() {
+		diags.Append(state.BlockedDomains.ElementsAs(ctx, &blocked, false)...)
+	}
+
+	profile := &onelogin.SelfRegistrationProfile{
+		Name:                  state.Name.ValueString(),
+		URL:                   state.URL.ValueString(),
+		Enabled:               state.Enabled.ValueBool(),
+		Title:                 state.Title.ValueString(),
+		Helptext:              state.Helptext.ValueString(),
+		ThankyouMessage:       state.ThankyouMessage.ValueString(),
+		Moderated:             state.Moderated.ValueBool(),
+		DefaultRoleID:         state.DefaultRoleID.ValueInt64Pointer(),
+		DefaultGroupID:        state.DefaultGroupID.ValueInt64Pointer(),
+		EmailVerificationType: state.EmailVerificationType.ValueString(),
+		DomainWhitelist:       strings.Join(allowed, ","),
+		DomainBlacklist:       strings.Join(blocked, ","),
+		DomainListStrategy:    onelogin.DomainListStrategyNone,
+	}
+
+	if len(allowed) > 0 {
+		profile.DomainListStrategy = onelogin.DomainListStrategyWhitelist
+	} else if len(blocked) > 0 {
+		profile.DomainListStrategy = onelogin.DomainListStrategyBlacklist
+	}
+
+	return profile, diags
+}
+
+func selfRegistrationProfileToState(ctx context.Context, profile *onelogin.SelfRegistrationProfile) (*oneloginSelfRegistrationProfile, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	// OneLogin returns empty strings for unset text
+	optionalString := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+
+	state := &oneloginSelfRegistrationProfile{
+		ID:                    types.Int64Value(profile.ID),
+		Name:                  types.StringValue(profile.Name),
+		URL:                   types.StringValue(profile.URL),
+		Enabled:               types.BoolValue(profile.Enabled),
+		Title:                 optionalString(profile.Title),
+		Helptext:              optionalString(profile.Helptext),
+		ThankyouMessage:       optionalString(profile.ThankyouMessage),
+		Moderated:             types.BoolValue(profile.Moderated),
+		DefaultRoleID:         types.Int64PointerValue(profile.DefaultRoleID),
+		DefaultGroupID:        types.Int64PointerValue(profile.DefaultGroupID),
+		EmailVerificationType: types.StringValue(profile.EmailVerificationType),
+		AllowedDomains:        types.ListNull(types.StringType),
+		BlockedDomains:        types.ListNull(types.StringType),
+	}
+
+	// Only the list selected by the strategy is in effect
+	var newDiags diag.Diagnostics
+	switch profile.DomainListStrategy {
+	case onelogin.DomainListStrategyWhitelist:
+		state.AllowedDomains, newDiags = types.ListValueFrom(ctx, types.StringType, splitDomainList(profile.DomainWhitelist))
+		diags.Append(newDiags...)
+	case onelogin.DomainListStrategyBlacklist:
+		state.BlockedDomains, newDiags = types.ListValueFrom(ctx, types.StringType, splitDomainList(profile.DomainBlacklist))
+		diags.Append(newDiags...)
+	}
+
+	fields := []oneloginSelfRegistrationProfileField{}
+	for _, field := range profile.Fields {
+		fields = append(fields, oneloginSelfRegistrationProfileField{
+			ID:                types.Int64Value(field.ID),
+			CustomAttributeID: types.Int64Value(field.CustomAttributeID),
+			Name:              types.StringValue(field.Name),
+		})
+	}
+	state.Fields, newDiags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginSelfRegistrationProfileFieldTypes()}, fields)
+	diags.Append(newDiags...)
+
+	return state, diags
+}
+
+func splitDomainList(list string) []string {
+	domains := []string{}
+	for _, domain := range strings.Split(list, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// fieldStateModifier keeps the computed id and name of a field from the
+// state.  Fields are matched to the state by custom_attribute_id instead of
+// by index, so reordering or adding fields doesn't change the others.
+type fieldStateModifier struct{}
+
+func (m fieldStateModifier) Description(ctx context.Context) string {
+	return "The value is kept from the field with the same custom_attribute_id in the state"
+}
+
+func (m fieldStateModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m fieldStateModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	field, diags := m.priorField(ctx, req.Path, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if field != nil {
+		resp.PlanValue = field.ID
+	}
+}
+
+func (m fieldStateModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	field, diags := m.priorField(ctx, req.Path, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if field != nil {
+		resp.PlanValue = field.Name
+	}
+}
+
+// priorField returns the field in the state with the custom attribute of
+// the planned field at p, or nil if there is none
+func (m fieldStateModifier) priorField(ctx context.Context, p path.Path, plan tfsdk.Plan, state tfsdk.State) (*oneloginSelfRegistrationProfileField, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	if state.Raw.IsNull() || plan.Raw.IsNull() {
+		return nil, diags
+	}
+
+	var customAttributeID types.Int64
+	diags.Append(plan.GetAttribute(ctx, p.ParentPath().AtName("custom_attribute_id"), &customAttributeID)...)
+	if diags.HasError() || customAttributeID.IsNull() || customAttributeID.IsUnknown() {
+		return nil, diags
+	}
+
+	var prior types.List
+	diags.Append(state.GetAttribute(ctx, path.Root("fields"), &prior)...)
+	if diags.HasError() || prior.IsNull() || prior.IsUnknown() {
+		return nil, diags
+	}
+
+	fields := []oneloginSelfRegistrationProfileField{}
+	diags.Append(prior.ElementsAs(ctx, &fields, false)...)
+	for i := range fields {
+		if fields[i].CustomAttributeID.Equal(customAttributeID) {
+			return &fields[i], diags
+		}
+	}
+	return nil, diags
+}
+
+func selfRegistrationProfileFieldsPath(profileID int64) string {
+	return fmt.Sprintf("%s/%v/fields", onelogin.PathSelfRegistrationProfiles, profileID)
+}
+
+func selfRegistrationProfileIDsByName(client *onelogin.Client) importLookupFunc {
+	return func(ctx context.Context, name string) ([]int64, error) {
+		var profiles []onelogin.SelfRegistrationProfile
+		err := client.ExecRequest(&onelogin.Request{
+			Context:   ctx,
+			Method:    onelogin.MethodGet,
+			Path:      onelogin.PathSelfRegistrationProfiles,
+			RespModel: &profiles,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := []int64{}
+		for _, profile := range profiles {
+			if profile.Name == name {
+				ids = append(ids, profile.ID)
+			}
+		}
+		return ids, nil
+	}
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fres "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *providerTestSuite) TestAccResourceSelfRegistrationProfile() {
+	name := "test_self_registration_" + s.randString()
+
+	config := func(moderated bool, domains string) string {
+		return s.providerConfig + fmt.Sprintf(`
+			resource "onelogin_role" "test" {
+				name = "%[1]v"
+			}
+
+			resource "onelogin_self_registration_profile" "test" {
+				name            = "%[1]v"
+				url             = "%[1]v"
+				moderated       = %[2]v
+				default_role_id = onelogin_role.test.id
+				allowed_domains = %[3]v
+			}
+		`, name, moderated, domains)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true, `["example.com"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_self_registration_profile.test", "name", name),
+					resource.TestCheckResourceAttr("onelogin_self_registration_profile.test", "moderated", "true"),
+					resource.TestCheckResourceAttrPair("onelogin_self_registration_profile.test", "default_role_id", "onelogin_role.test", "id"),
+					resource.TestCheckResourceAttr("onelogin_self_registration_profile.test", "allowed_domains.0", "example.com"),
+					resource.TestCheckNoResourceAttr("onelogin_self_registration_profile.test", "blocked_domains"),
+				),
+			},
+			{
+				Config: config(false, `["example.com", "example.org"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_self_registration_profile.test", "moderated", "false"),
+					resource.TestCheckResourceAttr("onelogin_self_registration_profile.test", "allowed_domains.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "onelogin_self_registration_profile.test",
+				ImportState:       true,
+				ImportStateId:     "name:" + name,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      config(false, `[]`),
+				ExpectError: regexp.MustCompile("Empty domain list"),
+			},
+		},
+	})
+}
+
+func TestSelfRegistrationProfileToState(t *testing.T) {
+	ctx := context.Background()
+	roleID := int64(42)
+
+	state, diags := selfRegistrationProfileToState(ctx, &onelogin.SelfRegistrationProfile{
+		ID:                 1234,
+		Name:               "test",
+		URL:                "test",
+		DefaultRoleID:      &roleID,
+		DomainWhitelist:    "allowed.example.com",
+		DomainBlacklist:    "example.com, example.org",
+		DomainListStrategy: onelogin.DomainListStrategyBlacklist,
+		Fields: []onelogin.SelfRegistrationProfileField{
+			{ID: 1, CustomAttributeID: 100, Name: "company"},
+		},
+	})
+	require.False(t, diags.HasError(), diags.Errors())
+
+	assert.Equal(t, int64(42), state.DefaultRoleID.ValueInt64())
+	assert.True(t, state.DefaultGroupID.IsNull())
+	assert.True(t, state.Title.IsNull())
+
+	// Only the list used by the strategy is read
+	assert.True(t, state.AllowedDomains.IsNull())
+	blocked := []string{}
+	diags = state.BlockedDomains.ElementsAs(ctx, &blocked, false)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, []string{"example.com", "example.org"}, blocked)
+	assert.Len(t, state.Fields.Elements(), 1)
+
+	native, diags := state.toNative(ctx)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, onelogin.DomainListStrategyBlacklist, native.DomainListStrategy)
+	assert.Equal(t, "example.com,example.org", native.DomainBlacklist)
+	assert.Equal(t, "", native.DomainWhitelist)
+	assert.Nil(t, native.Fields)
+}
+
+func TestDiffSelfRegistrationProfileFields(t *testing.T) {
+	existing := []onelogin.SelfRegistrationProfileField{
+		{ID: 1, CustomAttributeID: 100},
+		{ID: 2, CustomAttributeID: 200},
+	}
+
+	toAdd, toRemove := diffSelfRegistrationProfileFields([]int64{300, 100, 300}, existing)
+	assert.Equal(t, []int64{300}, toAdd)
+	assert.Equal(t, []onelogin.SelfRegistrationProfileField{{ID: 2, CustomAttributeID: 200}}, toRemove)
+
+	toAdd, toRemove = diffSelfRegistrationProfileFields([]int64{}, nil)
+	assert.Empty(t, toAdd)
+	assert.Empty(t, toRemove)
+}
+
+func TestFieldStateModifier(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := fres.SchemaResponse{}
+	NewOneLoginSelfRegistrationProfileResource(nil)().Schema(ctx, fres.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	s := schemaResp.Schema
+
+	prior, diags := selfRegistrationProfileToState(ctx, &onelogin.SelfRegistrationProfile{
+		ID:   1234,
+		Name: "test",
+		URL:  "test",
+		Fields: []onelogin.SelfRegistrationProfileField{
+			{ID: 1, CustomAttributeID: 100, Name: "company"},
+			{ID: 2, CustomAttributeID: 200, Name: "team"},
+		},
+	})
+	require.False(t, diags.HasError(), diags.Errors())
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, state.Set(ctx, prior).HasError())
+
+	// The fields are reordered and a field is added
+	fieldType := types.ObjectType{AttrTypes: oneloginSelfRegistrationProfileFieldTypes()}
+	plannedFields := []attr.Value{}
+	for _, customAttributeID := range []int64{200, 300, 100} {
+		plannedFields = append(plannedFields, types.ObjectValueMust(fieldType.AttrTypes, map[string]attr.Value{
+			"id":                  types.Int64Unknown(),
+			"custom_attribute_id": types.Int64Value(customAttributeID),
+			"name":                types.StringUnknown(),
+		}))
+	}
+	planned := *prior
+	planned.Fields = types.ListValueMust(fieldType, plannedFields)
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, plan.Set(ctx, &planned).HasError())
+
+	tests := []struct {
+		index int
+		id    types.Int64
+		name  types.String
+	}{
+		{index: 0, id: types.Int64Value(2), name: types.StringValue("team")},
+		{index: 1, id: types.Int64Unknown(), name: types.StringUnknown()},
+		{index: 2, id: types.Int64Value(1), name: types.StringValue("company")},
+	}
+	for _, test := range tests {
+		field := path.Root("fields").AtListIndex(test.index)
+
+		idResp := &planmodifier.Int64Response{PlanValue: types.Int64Unknown()}
+		fieldStateModifier{}.PlanModifyInt64(ctx, planmodifier.Int64Request{
+			Path:      field.AtName("id"),
+			Plan:      plan,
+			State:     state,
+			PlanValue: types.Int64Unknown(),
+		}, idResp)
+		require.False(t, idResp.Diagnostics.HasError(), idResp.Diagnostics.Errors())
+		assert.Equal(t, test.id, idResp.PlanValue, test.index)
+
+		nameResp := &planmodifier.StringResponse{PlanValue: types.StringUnknown()}
+		fieldStateModifier{}.PlanModifyString(ctx, planmodifier.StringRequest{
+			Path:      field.AtName("name"),
+			Plan:      plan,
+			State:     state,
+			PlanValue: types.StringUnknown(),
+		}, nameResp)
+		require.False(t, nameResp.Diagnostics.HasError(), nameResp.Diagnostics.Errors())
+		assert.Equal(t, test.name, nameResp.PlanValue, test.index)
+	}
+}
@@ -0,0 +1,603 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginSmartHookResource{}
+	_ resource.ResourceWithConfigure   = &oneloginSmartHookResource{}
+	_ resource.ResourceWithImportState = &oneloginSmartHookResource{}
+
+	_ datasource.DataSource              = &oneloginSmartHookLogsDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginSmartHookLogsDataSource{}
+)
+
+// OneLogin Smart Hook Resource
+
+type oneloginSmartHookResource struct {
+	client *onelogin.Client
+}
+
+type oneloginSmartHook struct {
+	ID         types.String `tfsdk:"id"`
+	Type       types.String `tfsdk:"type"`
+	Function   types.String `tfsdk:"function"`
+	Runtime    types.String `tfsdk:"runtime"`
+	Disabled   types.Bool   `tfsdk:"disabled"`
+	Timeout    types.Int64  `tfsdk:"timeout"`
+	Retries    types.Int64  `tfsdk:"retries"`
+	Packages   types.Map    `tfsdk:"packages"`
+	EnvVars    types.List   `tfsdk:"env_vars"`
+	Options    types.Object `tfsdk:"options"`
+	Conditions types.List   `tfsdk:"conditions"`
+	Status     types.String `tfsdk:"status"`
+}
+
+type oneloginSmartHookOptions struct {
+	RiskEnabled          types.Bool `tfsdk:"risk_enabled"`
+	LocationEnabled      types.Bool `tfsdk:"location_enabled"`
+	MFADeviceInfoEnabled types.Bool `tfsdk:"mfa_device_info_enabled"`
+}
+
+func oneloginSmartHookOptionsTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"risk_enabled":            types.BoolType,
+		"location_enabled":        types.BoolType,
+		"mfa_device_info_enabled": types.BoolType,
+	}
+}
+
+type oneloginSmartHookCondition struct {
+	Source   types.String `tfsdk:"source"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+}
+
+func oneloginSmartHookConditionTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"source":   types.StringType,
+		"operator": types.StringType,
+		"value":    types.StringType,
+	}
+}
+
+func NewOneLoginSmartHookResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginSmartHookResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginSmartHookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smart_hook"
+}
+
+func (r *oneloginSmartHookResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginSmartHookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "OneLogin Smart Hook. Environment variables used by the hook are managed with `onelogin_smart_hook_env_var`.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": rschema.StringAttribute{
+				MarkdownDescription: "Hook type, e.g. `pre-authentication` or `user-migration`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"function": rschema.StringAttribute{
+				MarkdownDescription: "JavaScript source of the hook, e.g. `file(\"hook.js\")`. " +
+					"The source is base64 encoded by the provider.",
+				Required: true,
+			},
+			"runtime": rschema.StringAttribute{
+				MarkdownDescription: "Node.js runtime, e.g. `nodejs18.x`",
+				Required:            true,
+			},
+			"disabled": rschema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeout": rschema.Int64Attribute{
+				MarkdownDescription: "Seconds the hook is allowed to run",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"retries": rschema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"packages": rschema.MapAttribute{
+				MarkdownDescription: "npm packages available to the hook, package name to version",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"env_vars": rschema.ListAttribute{
+				MarkdownDescription: "Names of the `onelogin_smart_hook_env_var` available to the hook",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"options": rschema.SingleNestedAttribute{
+				MarkdownDescription: "Additional context passed to pre-authentication hooks",
+				Attributes: map[string]rschema.Attribute{
+					"risk_enabled": rschema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"location_enabled": rschema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"mfa_device_info_enabled": rschema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+				},
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"conditions": rschema.ListNestedAttribute{
+				MarkdownDescription: "Only run the hook for users matching these conditions",
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"source": rschema.StringAttribute{
+							Required: true,
+						},
+						"operator": rschema.StringAttribute{
+							Required: true,
+						},
+						"value": rschema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"status": rschema.StringAttribute{
+				MarkdownDescription: "Deployment status of the hook, e.g. `ready`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *oneloginSmartHookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginSmartHook
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created onelogin.SmartHook
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      onelogin.PathSmartHooks,
+		Body:      native,
+		RespModel: &created,
+	})
+	if err != nil || created.ID == "" {
+		resp.Diagnostics.AddError(
+			"Error creating smart hook",
+			fmt.Sprintf("Could not create %s smart hook, got error: %v", state.Type.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginSmartHook
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginSmartHook
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	native, diags := state.toNative(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathSmartHooks, id),
+		Body:    native,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating smart hook",
+			fmt.Sprintf("Could not update smart hook %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginSmartHook
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathSmartHooks, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "smart hook to delete not found", map[string]interface{}{
+			"type": state.Type.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting smart hook",
+			fmt.Sprintf("Could not delete smart hook %s, got error: %s", id, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginSmartHookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := oneloginSmartHook{
+		ID:         types.StringValue(req.ID),
+		Packages:   types.MapNull(types.StringType),
+		EnvVars:    types.ListNull(types.StringType),
+		Conditions: types.ListNull(types.ObjectType{AttrTypes: oneloginSmartHookConditionTypes()}),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookResource) read(ctx context.Context, state *oneloginSmartHook, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueString()
+
+	var hook onelogin.SmartHook
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%s", onelogin.PathSmartHooks, id),
+		RespModel: &hook,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read smart hook %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	newState, diags := smartHookToState(ctx, &hook, state)
+	d.Append(diags...)
+	if d.HasError() {
+		return
+	}
+
+	diags = respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginSmartHook) toNative(ctx context.Context) (*onelogin.SmartHook, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	hook := &onelogin.SmartHook{
+		Type:       state.Type.ValueString(),
+		Function:   base64.StdEncoding.EncodeToString([]byte(state.Function.ValueString())),
+		Runtime:    state.Runtime.ValueString(),
+		Disabled:   state.Disabled.ValueBool(),
+		Timeout:    state.Timeout.ValueInt64(),
+		Retries:    state.Retries.ValueInt64(),
+		Packages:   map[string]string{},
+		EnvVars:    []string{},
+		Conditions: []onelogin.SmartHookCondition{},
+	}
+
+	// Use the OneLogin defaults when unset
+	if state.Timeout.IsNull() || state.Timeout.IsUnknown() {
+		hook.Timeout = 1
+	}
+
+	if !state.Packages.IsNull() && !state.Packages.IsUnknown() {
+		diags.Append(state.Packages.ElementsAs(ctx, &hook.Packages, false)...)
+	}
+
+	if !state.EnvVars.IsNull() && !state.EnvVars.IsUnknown() {
+		diags.Append(state.EnvVars.ElementsAs(ctx, &hook.EnvVars, false)...)
+	}
+
+	if !state.Options.IsNull() && !state.Options.IsUnknown() {
+		var options oneloginSmartHookOptions
+		diags.Append(state.Options.As(ctx, &options, basetypes.ObjectAsOptions{})...)
+		hook.Options = &onelogin.SmartHookOptions{
+			RiskEnabled:          options.RiskEnabled.ValueBool(),
+			LocationEnabled:      options.LocationEnabled.ValueBool(),
+			MFADeviceInfoEnabled: options.MFADeviceInfoEnabled.ValueBool(),
+		}
+	}
+
+	if !state.Conditions.IsNull() && !state.Conditions.IsUnknown() {
+		conditions := []oneloginSmartHookCondition{}
+		diags.Append(state.Conditions.ElementsAs(ctx, &conditions, false)...)
+		for _, c := range conditions {
+			hook.Conditions = append(hook.Conditions, onelogin.SmartHookCondition{
+				Source:   c.Source.ValueString(),
+				Operator: c.Operator.ValueString(),
+				Value:    c.Value.ValueString(),
+			})
+		}
+	}
+
+	return hook, diags
+}
+
+// smartHookToState converts a hook to state.  Empty packages, env vars and
+// conditions are left null when they are null in the prior state.
+func smartHookToState(ctx context.Context, hook *onelogin.SmartHook, prior *oneloginSmartHook) (*oneloginSmartHook, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	function, err := base64.StdEncoding.DecodeString(hook.Function)
+	if err != nil {
+		diags.AddError("Unable to decode smart hook function", err.Error())
+		return nil, diags
+	}
+
+	state := &oneloginSmartHook{
+		ID:         types.StringValue(hook.ID),
+		Type:       types.StringValue(hook.Type),
+		Function:   types.StringValue(string(function)),
+		Runtime:    types.StringValue(hook.Runtime),
+		Disabled:   types.BoolValue(hook.Disabled),
+		Timeout:    types.Int64Value(hook.Timeout),
+		Retries:    types.Int64Value(hook.Retries),
+		Packages:   types.MapNull(types.StringType),
+		EnvVars:    types.ListNull(types.StringType),
+		Conditions: types.ListNull(types.ObjectType{AttrTypes: oneloginSmartHookConditionTypes()}),
+		Status:     types.StringValue(hook.Status),
+	}
+
+	var newDiags diag.Diagnostics
+	if len(hook.Packages) > 0 || !prior.Packages.IsNull() {
+		packages := hook.Packages
+		if packages == nil {
+			packages = map[string]string{}
+		}
+		state.Packages, newDiags = types.MapValueFrom(ctx, types.StringType, packages)
+		diags.Append(newDiags...)
+	}
+
+	if len(hook.EnvVars) > 0 || !prior.EnvVars.IsNull() {
+		envVars := hook.EnvVars
+		if envVars == nil {
+			envVars = []string{}
+		}
+		state.EnvVars, newDiags = types.ListValueFrom(ctx, types.StringType, envVars)
+		diags.Append(newDiags...)
+	}
+
+	options := onelogin.SmartHookOptions{}
+	if hook.Options != nil {
+		options = *hook.Options
+	}
+	state.Options, newDiags = types.ObjectValueFrom(ctx, oneloginSmartHookOptionsTypes(), oneloginSmartHookOptions{
+		RiskEnabled:          types.BoolValue(options.RiskEnabled),
+		LocationEnabled:      types.BoolValue(options.LocationEnabled),
+		MFADeviceInfoEnabled: types.BoolValue(options.MFADeviceInfoEnabled),
+	})
+	diags.Append(newDiags...)
+
+	if len(hook.Conditions) > 0 || !prior.Conditions.IsNull() {
+		conditions := []oneloginSmartHookCondition{}
+		for _, c := range hook.Conditions {
+			conditions = append(conditions, oneloginSmartHookCondition{
+				Source:   types.StringValue(c.Source),
+				Operator: types.StringValue(c.Operator),
+				Value:    types.StringValue(c.Value),
+			})
+		}
+		state.Conditions, newDiags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginSmartHookConditionTypes()}, conditions)
+		diags.Append(newDiags...)
+	}
+
+	return state, diags
+}
+
+// OneLogin Smart Hook Logs Datasource
+
+type oneloginSmartHookLogsDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginSmartHookLogsModel struct {
+	HookID types.String `tfsdk:"hook_id"`
+	Logs   types.List   `tfsdk:"logs"`
+}
+
+type oneloginSmartHookLog struct {
+	RequestID     types.String `tfsdk:"request_id"`
+	CorrelationID types.String `tfsdk:"correlation_id"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	Events        types.List   `tfsdk:"events"`
+}
+
+func oneloginSmartHookLogTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"request_id":     types.StringType,
+		"correlation_id": types.StringType,
+		"created_at":     types.StringType,
+		"events":         types.ListType{ElemType: types.StringType},
+	}
+}
+
+func NewOneLoginSmartHookLogsDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginSmartHookLogsDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginSmartHookLogsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smart_hook_logs"
+}
+
+func (d *oneloginSmartHookLogsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginSmartHookLogsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "Recent executions of a OneLogin Smart Hook",
+		Attributes: map[string]dschema.Attribute{
+			"hook_id": dschema.StringAttribute{
+				Required: true,
+			},
+			"logs": dschema.ListNestedAttribute{
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: map[string]dschema.Attribute{
+						"request_id": dschema.StringAttribute{
+							Computed: true,
+						},
+						"correlation_id": dschema.StringAttribute{
+							Computed: true,
+						},
+						"created_at": dschema.StringAttribute{
+							Computed: true,
+						},
+						"events": dschema.ListAttribute{
+							MarkdownDescription: "Console output of the hook execution",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *oneloginSmartHookLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginSmartHookLogsModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.HookID.ValueString()
+
+	var logs []onelogin.SmartHookLog
+	err := d.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%s/logs", onelogin.PathSmartHooks, id),
+		RespModel: &logs,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read logs for smart hook %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	entries := []oneloginSmartHookLog{}
+	for _, log := range logs {
+		events := log.Events
+		if events == nil {
+			events = []string{}
+		}
+		eventList, diags := types.ListValueFrom(ctx, types.StringType, events)
+		resp.Diagnostics.Append(diags...)
+		entries = append(entries, oneloginSmartHookLog{
+			RequestID:     types.StringValue(log.RequestID),
+			CorrelationID: types.StringValue(log.CorrelationID),
+			CreatedAt:     types.StringValue(log.CreatedAt),
+			Events:        eventList,
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Logs, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginSmartHookLogTypes()}, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginSmartHookEnvVarResource{}
+	_ resource.ResourceWithConfigure   = &oneloginSmartHookEnvVarResource{}
+	_ resource.ResourceWithImportState = &oneloginSmartHookEnvVarResource{}
+)
+
+type oneloginSmartHookEnvVarResource struct {
+	client *onelogin.Client
+}
+
+type oneloginSmartHookEnvVar struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+func NewOneLoginSmartHookEnvVarResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginSmartHookEnvVarResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginSmartHookEnvVarResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smart_hook_env_var"
+}
+
+func (r *oneloginSmartHookEnvVarResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginSmartHookEnvVarResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Environment variable available to OneLogin Smart Hooks. " +
+			"OneLogin never returns the value, changes made outside of terraform are not detected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name referenced in the `env_vars` of a `onelogin_smart_hook`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (r *oneloginSmartHookEnvVarResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginSmartHookEnvVar
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created onelogin.SmartHookEnvVar
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPost,
+		Path:    onelogin.PathSmartHookEnvVars,
+		Body: &onelogin.SmartHookEnvVar{
+			Name:  state.Name.ValueString(),
+			Value: state.Value.ValueString(),
+		},
+		RespModel: &created,
+	})
+	if err != nil || created.ID == "" {
+		resp.Diagnostics.AddError(
+			"Error creating smart hook env var",
+			fmt.Sprintf("Could not create smart hook env var %s, got error: %v", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookEnvVarResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginSmartHookEnvVar
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookEnvVarResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginSmartHookEnvVar
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the value can be updated, the name forces replacement
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathSmartHookEnvVars, id),
+		Body: &onelogin.SmartHookEnvVar{
+			Value: state.Value.ValueString(),
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating smart hook env var",
+			fmt.Sprintf("Could not update smart hook env var %s, got error: %s", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookEnvVarResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginSmartHookEnvVar
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%s", onelogin.PathSmartHookEnvVars, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "smart hook env var to delete not found", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting smart hook env var",
+			fmt.Sprintf("Could not delete smart hook env var %s, got error: %s", state.Name.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState accepts either the id of the env var or name:<name>.  The value
+// cannot be imported and is set on the next apply.
+func (r *oneloginSmartHookEnvVarResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		var err error
+		id, err = smartHookEnvVarIDByName(ctx, r.client, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing ID for import smart hook env var",
+				"Could not parse ID "+req.ID+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	state := oneloginSmartHookEnvVar{
+		ID:    types.StringValue(id),
+		Value: types.StringNull(),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginSmartHookEnvVarResource) read(ctx context.Context, state *oneloginSmartHookEnvVar, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueString()
+
+	var envVar onelogin.SmartHookEnvVar
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%s", onelogin.PathSmartHookEnvVars, id),
+		RespModel: &envVar,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read smart hook env var %s, got error: %s", id, err),
+		)
+		return
+	}
+
+	// The value is write only, keep the value from the plan or prior state
+	newState := oneloginSmartHookEnvVar{
+		ID:    types.StringValue(envVar.ID),
+		Name:  types.StringValue(envVar.Name),
+		Value: state.Value,
+	}
+
+	diags := respState.Set(ctx, &newState)
+	d.Append(diags...)
+}
+
+func smartHookEnvVarIDByName(ctx context.Context, client *onelogin.Client, name string) (string, error) {
+	var envVars []onelogin.SmartHookEnvVar
+	err := client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      onelogin.PathSmartHookEnvVars,
+		RespModel: &envVars,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Env var names are unique
+	for _, envVar := range envVars {
+		if envVar.Name == name {
+			return envVar.ID, nil
+		}
+	}
+	return "", fmt.Errorf("smart hook env var %q: %w", name, onelogin.ErrNotFound)
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *providerTestSuite) TestAccResourceSmartHook() {
+	envVarName := "TEST_" + s.randString()
+
+	config := func(message, value string) string {
+		return s.providerConfig + fmt.Sprintf(`
+			resource "onelogin_smart_hook_env_var" "test" {
+				name  = "%[1]v"
+				value = "%[3]v"
+			}
+
+			resource "onelogin_smart_hook" "test" {
+				type     = "pre-authentication"
+				runtime  = "nodejs18.x"
+				disabled = true
+				function = <<-EOT
+					exports.handler = async (context) => {
+						console.log("%[2]v");
+						return { success: true, user: { policy_id: context.user.policy_id } };
+					};
+				EOT
+
+				env_vars = [onelogin_smart_hook_env_var.test.name]
+
+				conditions = [
+					{
+						source   = "roles"
+						operator = "!~"
+						value    = "0"
+					},
+				]
+			}
+
+			data "onelogin_smart_hook_logs" "test" {
+				hook_id = onelogin_smart_hook.test.id
+			}
+		`, envVarName, message, value)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("created", "secret"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_smart_hook_env_var.test", "name", envVarName),
+					resource.TestCheckResourceAttr("onelogin_smart_hook_env_var.test", "value", "secret"),
+					resource.TestCheckResourceAttr("onelogin_smart_hook.test", "type", "pre-authentication"),
+					resource.TestCheckResourceAttr("onelogin_smart_hook.test", "disabled", "true"),
+					resource.TestCheckResourceAttr("onelogin_smart_hook.test", "env_vars.0", envVarName),
+					resource.TestCheckResourceAttr("onelogin_smart_hook.test", "conditions.#", "1"),
+					resource.TestCheckResourceAttrSet("onelogin_smart_hook.test", "status"),
+					resource.TestCheckResourceAttrSet("data.onelogin_smart_hook_logs.test", "logs.#"),
+				),
+			},
+			{
+				Config: config("updated", "secret updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_smart_hook_env_var.test", "value", "secret updated"),
+					resource.TestMatchResourceAttr("onelogin_smart_hook.test", "function", regexp.MustCompile("updated")),
+				),
+			},
+			{
+				ResourceName:      "onelogin_smart_hook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:            "onelogin_smart_hook_env_var.test",
+				ImportState:             true,
+				ImportStateId:           "name:" + envVarName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
+		},
+	})
+}
+
+func TestSmartHookToState(t *testing.T) {
+	ctx := context.Background()
+	source := "exports.handler = async (context) => { return { success: true }; };\n"
+
+	hook := &onelogin.SmartHook{
+		ID:       "0b8bbfa6-d2b3-4c5c-a5b4-2b4ba6cd1d2f",
+		Type:     "pre-authentication",
+		Status:   "ready",
+		Runtime:  "nodejs18.x",
+		Timeout:  1,
+		Function: base64.StdEncoding.EncodeToString([]byte(source)),
+		Packages: map[string]string{},
+		EnvVars:  []string{"API_KEY"},
+	}
+
+	prior := &oneloginSmartHook{
+		Packages:   types.MapNull(types.StringType),
+		EnvVars:    types.ListNull(types.StringType),
+		Conditions: types.ListNull(types.ObjectType{AttrTypes: oneloginSmartHookConditionTypes()}),
+	}
+
+	state, diags := smartHookToState(ctx, hook, prior)
+	require.False(t, diags.HasError(), diags.Errors())
+
+	assert.Equal(t, source, state.Function.ValueString())
+	assert.Equal(t, "ready", state.Status.ValueString())
+	assert.True(t, state.Packages.IsNull())
+	assert.True(t, state.Conditions.IsNull())
+	assert.Len(t, state.EnvVars.Elements(), 1)
+	assert.False(t, state.Options.IsNull())
+
+	native, diags := state.toNative(ctx)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, hook.Function, native.Function)
+	assert.Equal(t, []string{"API_KEY"}, native.EnvVars)
+	assert.Equal(t, map[string]string{}, native.Packages)
+	assert.Equal(t, []onelogin.SmartHookCondition{}, native.Conditions)
+
+	// Function must be base64 encoded by OneLogin
+	hook.Function = "not base64!"
+	_, diags = smartHookToState(ctx, hook, prior)
+	assert.True(t, diags.HasError())
+}
@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginTrustedIDPResource{}
+	_ resource.ResourceWithConfigure   = &oneloginTrustedIDPResource{}
+	_ resource.ResourceWithImportState = &oneloginTrustedIDPResource{}
+)
+
+type oneloginTrustedIDPResource struct {
+	client *onelogin.Client
+}
+
+type oneloginTrustedIDP struct {
+	ID                   types.Int64  `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Enabled              types.Bool   `tfsdk:"enabled"`
+	Issuer               types.String `tfsdk:"issuer"`
+	SSOEndpoint          types.String `tfsdk:"sso_endpoint"`
+	Certificate          types.String `tfsdk:"certificate"`
+	LoginHint            types.Bool   `tfsdk:"login_hint"`
+	UserAttributeMapping types.String `tfsdk:"user_attribute_mapping"`
+	JITProvisioning      types.Bool   `tfsdk:"jit_provisioning"`
+}
+
+func NewOneLoginTrustedIDPResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginTrustedIDPResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginTrustedIDPResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trusted_idp"
+}
+
+func (r *oneloginTrustedIDPResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginTrustedIDPResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	optionalBool := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			MarkdownDescription: description,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OneLogin Trusted IdP used to sign in users authenticated by an external SAML identity provider.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"enabled": optionalBool(""),
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the SAML assertions sent by the IdP",
+				Required:            true,
+			},
+			"sso_endpoint": schema.StringAttribute{
+				MarkdownDescription: "SAML SSO url of the IdP",
+				Required:            true,
+			},
+			"certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded signing certificate of the IdP",
+				Required:            true,
+			},
+			"login_hint": optionalBool("Send the username to the IdP as a login hint"),
+			"user_attribute_mapping": schema.StringAttribute{
+				MarkdownDescription: "OneLogin user attribute matched against the NameID of the assertion, e.g. `email` or `username`",
+				Required:            true,
+			},
+			"jit_provisioning": optionalBool("Create users that do not exist in OneLogin on first sign in"),
+		},
+	}
+}
+
+func (r *oneloginTrustedIDPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginTrustedIDP
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created onelogin.TrustedIDP
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodPost,
+		Path:      onelogin.PathTrustedIDPs,
+		Body:      state.toNative(),
+		RespModel: &created,
+	})
+	if err != nil || created.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error creating trusted idp",
+			fmt.Sprintf("Could not create trusted idp %s, got error: %v", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(created.ID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginTrustedIDPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginTrustedIDP
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginTrustedIDPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state oneloginTrustedIDP
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPut,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathTrustedIDPs, id),
+		Body:    state.toNative(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating trusted idp",
+			fmt.Sprintf("Could not update trusted idp %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginTrustedIDPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginTrustedIDP
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v", onelogin.PathTrustedIDPs, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "trusted idp to delete not found", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"id":   id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting trusted idp",
+			fmt.Sprintf("Could not delete trusted idp %v, got error: %s", id, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginTrustedIDPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(ctx, "trusted idp", req.ID, map[string]importLookupFunc{
+		"name": trustedIDPIDsByName(r.client),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import trusted idp",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginTrustedIDP{
+		ID: types.Int64Value(id),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginTrustedIDPResource) read(ctx context.Context, state *oneloginTrustedIDP, respState *tfsdk.State, d *diag.Diagnostics) {
+	id := state.ID.ValueInt64()
+
+	var idp onelogin.TrustedIDP
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v", onelogin.PathTrustedIDPs, id),
+		RespModel: &idp,
+	})
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read trusted idp %v, got error: %s", id, err),
+		)
+		return
+	}
+
+	newState := trustedIDPToState(&idp)
+
+	// OneLogin normalizes the certificate, keep the configured formatting
+	// when the certificate is unchanged
+	if !state.Certificate.IsNull() && normalizeCertificate(state.Certificate.ValueString()) == normalizeCertificate(idp.Certificate) {
+		newState.Certificate = state.Certificate
+	}
+
+	diags := respState.Set(ctx, newState)
+	d.Append(diags...)
+}
+
+func (state *oneloginTrustedIDP) toNative() *onelogin.TrustedIDP {
+	return &onelogin.TrustedIDP{
+		Name:                 state.Name.ValueString(),
+		Enabled:              state.Enabled.ValueBool(),
+		Issuer:               state.Issuer.ValueString(),
+		SSOEndpoint:          state.SSOEndpoint.ValueString(),
+		Certificate:          state.Certificate.ValueString(),
+		LoginHint:            state.LoginHint.ValueBool(),
+		UserAttributeMapping: state.UserAttributeMapping.ValueString(),
+		JITProvisioning:      state.JITProvisioning.ValueBool(),
+	}
+}
+
+func trustedIDPToState(idp *onelogin.TrustedIDP) *oneloginTrustedIDP {
+	return &oneloginTrustedIDP{
+		ID:                   types.Int64Value(idp.ID),
+		Name:                 types.StringValue(idp.Name),
+		Enabled:              types.BoolValue(idp.Enabled),
+		Issuer:               types.StringValue(idp.Issuer),
+		SSOEndpoint:          types.StringValue(idp.SSOEndpoint),
+		Certificate:          types.StringValue(idp.Certificate),
+		LoginHint:            types.BoolValue(idp.LoginHint),
+		UserAttributeMapping: types.StringValue(idp.UserAttributeMapping),
+		JITProvisioning:      types.BoolValue(idp.JITProvisioning),
+	}
+}
+
+// normalizeCertificate strips whitespace so that certificates can be compared
+// regardless of line endings and wrapping
+func normalizeCertificate(cert string) string {
+	return strings.Join(strings.Fields(cert), "")
+}
+
+func trustedIDPIDsByName(client *onelogin.Client) importLookupFunc {
+	return func(ctx context.Context, name string) ([]int64, error) {
+		var idps []onelogin.TrustedIDP
+		err := client.ExecRequest(&onelogin.Request{
+			Context:   ctx,
+			Method:    onelogin.MethodGet,
+			Path:      onelogin.PathTrustedIDPs,
+			RespModel: &idps,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := []int64{}
+		for _, idp := range idps {
+			if idp.Name == name {
+				ids = append(ids, idp.ID)
+			}
+		}
+		return ids, nil
+	}
+}
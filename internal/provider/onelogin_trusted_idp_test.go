@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *providerTestSuite) TestAccResourceTrustedIDP() {
+	name := "test_trusted_idp_" + s.randString()
+	cert := testCertificate(s.T(), name)
+
+	config := func(loginHint bool) string {
+		return s.providerConfig + fmt.Sprintf(`
+			resource "onelogin_trusted_idp" "test" {
+				name                   = "%[1]v"
+				issuer                 = "https://%[1]v.example.com/saml"
+				sso_endpoint           = "https://%[1]v.example.com/saml/sso"
+				certificate            = <<-EOT
+%[2]s
+				EOT
+				user_attribute_mapping = "email"
+				login_hint             = %[3]v
+				jit_provisioning       = false
+			}
+		`, name, strings.TrimSpace(cert), loginHint)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_trusted_idp.test", "name", name),
+					resource.TestCheckResourceAttr("onelogin_trusted_idp.test", "login_hint", "false"),
+					resource.TestCheckResourceAttr("onelogin_trusted_idp.test", "user_attribute_mapping", "email"),
+					resource.TestCheckResourceAttrSet("onelogin_trusted_idp.test", "enabled"),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_trusted_idp.test", "login_hint", "true"),
+				),
+			},
+			{
+				ResourceName:      "onelogin_trusted_idp.test",
+				ImportState:       true,
+				ImportStateId:     "name:" + name,
+				ImportStateVerify: true,
+				// The imported certificate is formatted by OneLogin
+				ImportStateVerifyIgnore: []string{"certificate"},
+			},
+		},
+	})
+}
+
+func TestTrustedIDPToState(t *testing.T) {
+	cert := testCertificate(t, "test")
+
+	state := trustedIDPToState(&onelogin.TrustedIDP{
+		ID:                   1234,
+		Name:                 "test",
+		Issuer:               "https://idp.example.com",
+		Certificate:          cert,
+		UserAttributeMapping: "email",
+		JITProvisioning:      true,
+	})
+
+	assert.Equal(t, int64(1234), state.ID.ValueInt64())
+	assert.True(t, state.JITProvisioning.ValueBool())
+	assert.False(t, state.LoginHint.ValueBool())
+
+	native := state.toNative()
+	assert.Equal(t, cert, native.Certificate)
+	assert.Equal(t, "email", native.UserAttributeMapping)
+
+	// Wrapping and line endings do not change the certificate
+	crlf := strings.ReplaceAll(cert, "\n", "\r\n") + "\n"
+	assert.Equal(t, normalizeCertificate(cert), normalizeCertificate(crlf))
+	assert.NotEqual(t, normalizeCertificate(cert), normalizeCertificate(testCertificate(t, "other")))
+}
+
+// testCertificate returns a PEM encoded self-signed certificate.  The key
+// and validity are fixed and RSA signatures are deterministic, so the
+// certificate of a common name is the same in every run, as needed to
+// replay cassettes.
+func testCertificate(t *testing.T, commonName string) string {
+	keyPEM, err := os.ReadFile("testdata/trusted_idp_key.pem")
+	require.NoError(t, err)
+	block, _ := pem.Decode(keyPEM)
+	require.NotNil(t, block)
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:       big.NewInt(1),
+		Subject:            pkix.Name{CommonName: commonName},
+		NotBefore:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:           time.Date(2124, 1, 1, 0, 0, 0, 0, time.UTC),
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/ghaggin/terraform-provider-onelogin/internal/util"
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
@@ -284,7 +283,10 @@ func (r *oneloginUserResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *oneloginUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	id, err := resolveImportID(ctx, "user", req.ID, map[string]importLookupFunc{
+		"username": userIDsBy(r.client, "username", func(u *onelogin.User) string { return u.Username }),
+		"email":    userIDsBy(r.client, "email", func(u *onelogin.User) string { return u.Email }),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error parsing ID for import user",
@@ -294,7 +296,7 @@ func (r *oneloginUserResource) ImportState(ctx context.Context, req resource.Imp
 	}
 
 	state := oneloginUserModel{
-		ID: types.Int64Value(int64(id)),
+		ID: types.Int64Value(id),
 	}
 
 	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
@@ -0,0 +1,591 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &oneloginUserMFADeviceResource{}
+	_ resource.ResourceWithConfigure   = &oneloginUserMFADeviceResource{}
+	_ resource.ResourceWithImportState = &oneloginUserMFADeviceResource{}
+
+	_ datasource.DataSource              = &oneloginAuthFactorsDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginAuthFactorsDataSource{}
+	_ datasource.DataSource              = &oneloginUserMFADevicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &oneloginUserMFADevicesDataSource{}
+)
+
+type oneloginUserMFADeviceResource struct {
+	client *onelogin.Client
+}
+
+// Devices can't be updated, every configurable attribute requires
+// a new enrollment.
+type oneloginUserMFADevice struct {
+	ID              types.Int64  `tfsdk:"id"`
+	UserID          types.Int64  `tfsdk:"user_id"`
+	FactorID        types.Int64  `tfsdk:"factor_id"`
+	DisplayName     types.String `tfsdk:"display_name"`
+	PhoneNumber     types.String `tfsdk:"phone_number"`
+	Verified        types.Bool   `tfsdk:"verified"`
+	AuthFactorName  types.String `tfsdk:"auth_factor_name"`
+	TypeDisplayName types.String `tfsdk:"type_display_name"`
+	Default         types.Bool   `tfsdk:"default"`
+}
+
+func NewOneLoginUserMFADeviceResource(client *onelogin.Client) newResourceFunc {
+	return func() resource.Resource {
+		return &oneloginUserMFADeviceResource{
+			client: client,
+		}
+	}
+}
+
+func (r *oneloginUserMFADeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_mfa_device"
+}
+
+func (r *oneloginUserMFADeviceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *oneloginUserMFADeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enrolls a OneLogin user in an MFA factor, e.g. to pre-enroll service accounts. " +
+			"The device is removed from the user on destroy. " +
+			"Only factors that are verified on enrollment can be managed, see `verified`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the enrolled device",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"factor_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the factor to enroll in, see the `onelogin_auth_factors` data source",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the device shown to the user",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"phone_number": schema.StringAttribute{
+				MarkdownDescription: "Phone number for SMS and Voice factors. OneLogin does not return the number, changes outside of terraform are not detected.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification step of SMS, Voice and Email factors. " +
+					"Enrollments that need verification by the user fail to create.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"auth_factor_name": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type_display_name": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default": schema.BoolAttribute{
+				MarkdownDescription: "Whether the device is the default factor of the user",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *oneloginUserMFADeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state oneloginUserMFADevice
+	diags := req.Plan.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := state.UserID.ValueInt64()
+
+	// The registration response doesn't include the device, the new
+	// device is found by comparing the devices before and after.
+	before, err := listMFADevices(ctx, r.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read mfa devices of user %v, got error: %s", userID, err),
+		)
+		return
+	}
+
+	var registration onelogin.MFARegistrationStatus
+	err = r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodPost,
+		Path:    fmt.Sprintf("%s/%v/registrations", onelogin.PathMFAUsers, userID),
+		Body: &onelogin.MFARegistration{
+			FactorID:    state.FactorID.ValueInt64(),
+			DisplayName: state.DisplayName.ValueString(),
+			Number:      state.PhoneNumber.ValueString(),
+			Verified:    state.Verified.ValueBool(),
+		},
+		RespModel: &registration,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user mfa device",
+			fmt.Sprintf("Could not enroll user %v in factor %v, got error: %s", userID, state.FactorID.ValueInt64(), err),
+		)
+		return
+	}
+
+	after, err := listMFADevices(ctx, r.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read mfa devices of user %v, got error: %s", userID, err),
+		)
+		return
+	}
+
+	device := newMFADevice(before, after)
+	if device == nil {
+		resp.Diagnostics.AddError(
+			"Error creating user mfa device",
+			fmt.Sprintf("Enrollment %s of user %v has status %q and did not create a device. "+
+				"Only enrollments that don't require verification by the user can be managed.",
+				registration.ID, userID, registration.Status),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(device.DeviceID)
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginUserMFADeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneloginUserMFADevice
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginUserMFADeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Error updating user mfa device",
+		"User mfa devices can't be updated, all changes require replacement",
+	)
+}
+
+func (r *oneloginUserMFADeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneloginUserMFADevice
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := state.UserID.ValueInt64()
+	id := state.ID.ValueInt64()
+	err := r.client.ExecRequest(&onelogin.Request{
+		Context: ctx,
+		Method:  onelogin.MethodDelete,
+		Path:    fmt.Sprintf("%s/%v/devices/%v", onelogin.PathMFAUsers, userID, id),
+	})
+
+	// consider NotFound a success
+	if err == onelogin.ErrNotFound {
+		tflog.Warn(ctx, "user mfa device to delete not found", map[string]interface{}{
+			"user_id": userID,
+			"id":      id,
+		})
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting user mfa device",
+			fmt.Sprintf("Could not remove device %v from user %v, got error: %s", id, userID, err),
+		)
+		return
+	}
+}
+
+func (r *oneloginUserMFADeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	userID, id, err := parseChildImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ID for import user mfa device",
+			"Could not parse ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state := oneloginUserMFADevice{
+		ID:          types.Int64Value(id),
+		UserID:      types.Int64Value(userID),
+		PhoneNumber: types.StringNull(),
+		Verified:    types.BoolNull(),
+	}
+
+	r.read(ctx, &state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *oneloginUserMFADeviceResource) read(ctx context.Context, state *oneloginUserMFADevice, respState *tfsdk.State, d *diag.Diagnostics) {
+	userID := state.UserID.ValueInt64()
+	id := state.ID.ValueInt64()
+
+	devices, err := listMFADevices(ctx, r.client, userID)
+	if err == nil {
+		err = onelogin.ErrNotFound
+		for _, device := range devices {
+			if device.DeviceID == id {
+				mfaDeviceToState(&device, state)
+				err = nil
+				break
+			}
+		}
+	}
+	if err != nil {
+		d.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read device %v of user %v, got error: %s", id, userID, err),
+		)
+		return
+	}
+
+	// The factor isn't returned with the device, imported devices are
+	// matched to the factor by name.
+	if state.FactorID.IsNull() || state.FactorID.IsUnknown() {
+		factors, err := listAuthFactors(ctx, r.client, userID)
+		if err != nil {
+			d.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read auth factors of user %v, got error: %s", userID, err),
+			)
+			return
+		}
+		for _, factor := range factors {
+			if factor.AuthFactorName == state.AuthFactorName.ValueString() {
+				state.FactorID = types.Int64Value(factor.FactorID)
+				break
+			}
+		}
+		if state.FactorID.IsNull() || state.FactorID.IsUnknown() {
+			d.AddError(
+				"client error",
+				fmt.Sprintf("Unable to read device %v of user %v, got error: no auth factor named %q", id, userID, state.AuthFactorName.ValueString()),
+			)
+			return
+		}
+	}
+
+	diags := respState.Set(ctx, state)
+	d.Append(diags...)
+}
+
+// mfaDeviceToState copies the attributes returned by OneLogin into state.
+// The factor, phone number and verified flag are only known from config.
+func mfaDeviceToState(device *onelogin.MFADevice, state *oneloginUserMFADevice) {
+	state.ID = types.Int64Value(device.DeviceID)
+	state.DisplayName = types.StringValue(device.UserDisplayName)
+	state.AuthFactorName = types.StringValue(device.AuthFactorName)
+	state.TypeDisplayName = types.StringValue(device.TypeDisplayName)
+	state.Default = types.BoolValue(device.Default)
+}
+
+// newMFADevice returns the first device in after that isn't in before
+func newMFADevice(before, after []onelogin.MFADevice) *onelogin.MFADevice {
+	existing := map[int64]bool{}
+	for _, device := range before {
+		existing[device.DeviceID] = true
+	}
+
+	for i := range after {
+		if !existing[after[i].DeviceID] {
+			return &after[i]
+		}
+	}
+	return nil
+}
+
+func listMFADevices(ctx context.Context, client *onelogin.Client, userID int64) ([]onelogin.MFADevice, error) {
+	devices := []onelogin.MFADevice{}
+	err := client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v/devices", onelogin.PathMFAUsers, userID),
+		RespModel: &devices,
+	})
+	return devices, err
+}
+
+func listAuthFactors(ctx context.Context, client *onelogin.Client, userID int64) ([]onelogin.AuthFactor, error) {
+	factors := []onelogin.AuthFactor{}
+	err := client.ExecRequest(&onelogin.Request{
+		Context:   ctx,
+		Method:    onelogin.MethodGet,
+		Path:      fmt.Sprintf("%s/%v/factors", onelogin.PathMFAUsers, userID),
+		RespModel: &factors,
+	})
+	return factors, err
+}
+
+type oneloginAuthFactorsDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginAuthFactorsDataSourceModel struct {
+	UserID  types.Int64 `tfsdk:"user_id"`
+	Factors types.List  `tfsdk:"factors"`
+}
+
+type oneloginAuthFactor struct {
+	FactorID       types.Int64  `tfsdk:"factor_id"`
+	Name           types.String `tfsdk:"name"`
+	AuthFactorName types.String `tfsdk:"auth_factor_name"`
+}
+
+func oneloginAuthFactorTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"factor_id":        types.Int64Type,
+		"name":             types.StringType,
+		"auth_factor_name": types.StringType,
+	}
+}
+
+func NewOneLoginAuthFactorsDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginAuthFactorsDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginAuthFactorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_factors"
+}
+
+func (d *oneloginAuthFactorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginAuthFactorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "MFA factors a OneLogin user can enroll in",
+		Attributes: map[string]dschema.Attribute{
+			"user_id": dschema.Int64Attribute{
+				Required: true,
+			},
+			"factors": dschema.ListNestedAttribute{
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: map[string]dschema.Attribute{
+						"factor_id": dschema.Int64Attribute{
+							Computed: true,
+						},
+						"name": dschema.StringAttribute{
+							MarkdownDescription: "Name of the factor, e.g. `OneLogin Email`",
+							Computed:            true,
+						},
+						"auth_factor_name": dschema.StringAttribute{
+							MarkdownDescription: "Type of the factor, e.g. `Email`",
+							Computed:            true,
+						},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *oneloginAuthFactorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginAuthFactorsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueInt64()
+	factors, err := listAuthFactors(ctx, d.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read auth factors of user %v, got error: %s", userID, err),
+		)
+		return
+	}
+
+	models := make([]oneloginAuthFactor, len(factors))
+	for i, factor := range factors {
+		models[i] = oneloginAuthFactor{
+			FactorID:       types.Int64Value(factor.FactorID),
+			Name:           types.StringValue(factor.Name),
+			AuthFactorName: types.StringValue(factor.AuthFactorName),
+		}
+	}
+
+	data.Factors, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginAuthFactorTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+type oneloginUserMFADevicesDataSource struct {
+	client *onelogin.Client
+}
+
+type oneloginUserMFADevicesDataSourceModel struct {
+	UserID  types.Int64 `tfsdk:"user_id"`
+	Devices types.List  `tfsdk:"devices"`
+}
+
+type oneloginMFADevice struct {
+	DeviceID        types.Int64  `tfsdk:"device_id"`
+	UserDisplayName types.String `tfsdk:"user_display_name"`
+	TypeDisplayName types.String `tfsdk:"type_display_name"`
+	AuthFactorName  types.String `tfsdk:"auth_factor_name"`
+	Default         types.Bool   `tfsdk:"default"`
+}
+
+func oneloginMFADeviceTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"device_id":         types.Int64Type,
+		"user_display_name": types.StringType,
+		"type_display_name": types.StringType,
+		"auth_factor_name":  types.StringType,
+		"default":           types.BoolType,
+	}
+}
+
+func NewOneLoginUserMFADevicesDataSource(client *onelogin.Client) newDataSourceFunc {
+	return func() datasource.DataSource {
+		return &oneloginUserMFADevicesDataSource{
+			client: client,
+		}
+	}
+}
+
+func (d *oneloginUserMFADevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_mfa_devices"
+}
+
+func (d *oneloginUserMFADevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *oneloginUserMFADevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: "MFA devices a OneLogin user is enrolled in",
+		Attributes: map[string]dschema.Attribute{
+			"user_id": dschema.Int64Attribute{
+				Required: true,
+			},
+			"devices": dschema.ListNestedAttribute{
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: map[string]dschema.Attribute{
+						"device_id": dschema.Int64Attribute{
+							Computed: true,
+						},
+						"user_display_name": dschema.StringAttribute{
+							Computed: true,
+						},
+						"type_display_name": dschema.StringAttribute{
+							Computed: true,
+						},
+						"auth_factor_name": dschema.StringAttribute{
+							Computed: true,
+						},
+						"default": dschema.BoolAttribute{
+							Computed: true,
+						},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *oneloginUserMFADevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data oneloginUserMFADevicesDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueInt64()
+	devices, err := listMFADevices(ctx, d.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"client error",
+			fmt.Sprintf("Unable to read mfa devices of user %v, got error: %s", userID, err),
+		)
+		return
+	}
+
+	models := make([]oneloginMFADevice, len(devices))
+	for i, device := range devices {
+		models[i] = oneloginMFADevice{
+			DeviceID:        types.Int64Value(device.DeviceID),
+			UserDisplayName: types.StringValue(device.UserDisplayName),
+			TypeDisplayName: types.StringValue(device.TypeDisplayName),
+			AuthFactorName:  types.StringValue(device.AuthFactorName),
+			Default:         types.BoolValue(device.Default),
+		}
+	}
+
+	data.Devices, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: oneloginMFADeviceTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
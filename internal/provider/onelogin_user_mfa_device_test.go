@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *providerTestSuite) TestAccResourceUserMFADevice() {
+	username := "test_mfa_device_" + s.randString()
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig + fmt.Sprintf(`
+					resource "onelogin_user" "test" {
+						username = "%[1]v"
+						email    = "%[1]v@example.com"
+					}
+
+					data "onelogin_auth_factors" "test" {
+						user_id = onelogin_user.test.id
+					}
+
+					resource "onelogin_user_mfa_device" "test" {
+						user_id      = onelogin_user.test.id
+						factor_id    = one([for f in data.onelogin_auth_factors.test.factors : f.factor_id if f.auth_factor_name == "Email"])
+						display_name = "test email"
+						verified     = true
+					}
+
+					data "onelogin_user_mfa_devices" "test" {
+						user_id = onelogin_user_mfa_device.test.user_id
+					}
+				`, username),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onelogin_user_mfa_device.test", "auth_factor_name", "Email"),
+					resource.TestCheckResourceAttr("onelogin_user_mfa_device.test", "display_name", "test email"),
+					resource.TestCheckResourceAttr("data.onelogin_user_mfa_devices.test", "devices.#", "1"),
+					resource.TestCheckResourceAttrPair("data.onelogin_user_mfa_devices.test", "devices.0.device_id", "onelogin_user_mfa_device.test", "id"),
+				),
+			},
+			{
+				ResourceName:            "onelogin_user_mfa_device.test",
+				ImportState:             true,
+				ImportStateIdFunc:       childImportIDFunc("onelogin_user_mfa_device.test", "user_id", "id"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"verified"},
+			},
+		},
+	})
+}
+
+func TestNewMFADevice(t *testing.T) {
+	before := []onelogin.MFADevice{
+		{DeviceID: 1, AuthFactorName: "Email"},
+	}
+	after := []onelogin.MFADevice{
+		{DeviceID: 1, AuthFactorName: "Email"},
+		{DeviceID: 2, AuthFactorName: "SMS", UserDisplayName: "phone", Default: true},
+	}
+
+	device := newMFADevice(before, after)
+	require.NotNil(t, device)
+	assert.Equal(t, int64(2), device.DeviceID)
+
+	state := oneloginUserMFADevice{}
+	mfaDeviceToState(device, &state)
+	assert.Equal(t, int64(2), state.ID.ValueInt64())
+	assert.Equal(t, "phone", state.DisplayName.ValueString())
+	assert.True(t, state.Default.ValueBool())
+
+	assert.Nil(t, newMFADevice(after, after))
+	assert.Nil(t, newMFADevice(nil, nil))
+}
@@ -5,12 +5,11 @@ import (
 	"regexp"
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func (s *providerTestSuite) TestAccDatasourceUser() {
-	username := "test_user_" + acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	username := "test_user_" + s.randString()
 	var id int64
 
 	resource.Test(s.T(), resource.TestCase{
@@ -110,6 +109,13 @@ func (s *providerTestSuite) TestAccResourceUser() {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
+			{
+				ResourceName:            "onelogin_user.test_user",
+				ImportState:             true,
+				ImportStateId:           "username:" + name,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
 
 			// Update and read testing
 			{
@@ -2,11 +2,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"os"
 	"time"
 
 	"github.com/ghaggin/terraform-provider-onelogin/internal/util"
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -27,6 +31,10 @@ type oneloginProvider struct {
 	// testing.
 	version string
 
+	// transport replaces the http transport of the client, it is only
+	// set by tests to record and replay cassettes.
+	transport http.RoundTripper
+
 	client onelogin.Client
 }
 
@@ -36,6 +44,18 @@ type oneLoginProviderModel struct {
 	CLientSecret types.String `tfsdk:"client_secret"`
 	Subdomain    types.String `tfsdk:"subdomain"`
 	Region       types.String `tfsdk:"region"`
+	ReadCache    types.Bool   `tfsdk:"read_cache"`
+
+	Client *oneLoginProviderClientModel `tfsdk:"client"`
+}
+
+// oneLoginProviderClientModel tunes the requests made by the client
+type oneLoginProviderClientModel struct {
+	RequestTimeout        types.String `tfsdk:"request_timeout"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryWait             types.String `tfsdk:"retry_wait"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	HTTPTrace             types.Bool   `tfsdk:"http_trace"`
 }
 
 func (p *oneloginProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,21 +67,56 @@ func (p *oneloginProvider) Schema(ctx context.Context, req provider.SchemaReques
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"client_id": schema.StringAttribute{
-				MarkdownDescription: "Admin oauth client id",
-				Required:            true,
+				MarkdownDescription: "Admin oauth client id. Defaults to the `ONELOGIN_CLIENT_ID` environment variable.",
+				Optional:            true,
 			},
 			"client_secret": schema.StringAttribute{
-				MarkdownDescription: "Admin oauth client secret",
-				Required:            true,
+				MarkdownDescription: "Admin oauth client secret. Defaults to the `ONELOGIN_CLIENT_SECRET` environment variable.",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"subdomain": schema.StringAttribute{
-				MarkdownDescription: "Instance subdomain",
-				Required:            true,
+				MarkdownDescription: "Instance subdomain. Defaults to the `ONELOGIN_SUBDOMAIN` environment variable.",
+				Optional:            true,
 			},
 			"region": schema.StringAttribute{
-				MarkdownDescription: "Region",
-				Optional:            true,
+				MarkdownDescription: "Region. Defaults to the `ONELOGIN_REGION` environment variable. " +
+					"Currently unused, requests are sent to the instance subdomain in every region.",
+				Optional: true,
+			},
+			"read_cache": schema.BoolAttribute{
+				MarkdownDescription: "List roles and mappings once per operation and serve individual reads from the list. " +
+					"Reduces the number of requests made during refresh on large tenants. Any write clears the cache.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client": schema.SingleNestedBlock{
+				MarkdownDescription: "Tuning of the requests made to OneLogin",
+				Attributes: map[string]schema.Attribute{
+					"request_timeout": schema.StringAttribute{
+						MarkdownDescription: "Timeout of a single request as a duration, e.g. `90s`. Defaults to `60s`.",
+						Optional:            true,
+					},
+					"max_retries": schema.Int64Attribute{
+						MarkdownDescription: "Number of times a request is retried on rate limit and gateway errors. " +
+							"Creates (POST requests) are only retried when rate limited, because a create that fails with a gateway error may have succeeded. " +
+							"Requests that need their own retries, e.g. to wait for new objects to be readable, are not affected. Defaults to 0.",
+						Optional: true,
+					},
+					"retry_wait": schema.StringAttribute{
+						MarkdownDescription: "Wait before the first retry as a duration, doubled for every following retry. Defaults to `1s`.",
+						Optional:            true,
+					},
+					"max_concurrent_requests": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of requests in flight at once. Defaults to no limit.",
+						Optional:            true,
+					},
+					"http_trace": schema.BoolAttribute{
+						MarkdownDescription: "Log every http request and response at `TRACE` level, including authentication requests. Api requests are always logged at `TRACE` level with their redacted bodies, see `TF_LOG=trace`.",
+						Optional:            true,
+					},
+				},
 			},
 		},
 	}
@@ -75,25 +130,12 @@ func (p *oneloginProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
-	if data.ClientID.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(path.Root("client_id"), "client_id is required", "")
-	}
-
-	if data.CLientSecret.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(path.Root("client_secret"), "client_secret is required", "")
-	}
-
-	if data.Subdomain.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(path.Root("url"), "url is required", "")
-	}
-
+	resp.Diagnostics.Append(data.withEnvDefaults(os.Getenv)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, err := onelogin.NewClient(&onelogin.ClientConfig{
+	config := &onelogin.ClientConfig{
 		ClientID:     data.ClientID.ValueString(),
 		ClientSecret: data.CLientSecret.ValueString(),
 		Subdomain:    data.Subdomain.ValueString(),
@@ -103,9 +145,22 @@ func (p *oneloginProvider) Configure(ctx context.Context, req provider.Configure
 		// the state inconsistent.
 		Timeout: 60 * time.Second,
 
+		ReadCache: data.ReadCache.ValueBool(),
+
 		// Pass the terraform logger to the onelogin client
 		Logger: &util.TFLogger{},
-	})
+
+		Transport: p.transport,
+	}
+
+	if data.Client != nil {
+		resp.Diagnostics.Append(data.Client.applyTo(config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client, err := onelogin.NewClient(config)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create client", err.Error())
 		return
@@ -114,6 +169,107 @@ func (p *oneloginProvider) Configure(ctx context.Context, req provider.Configure
 	p.client = *client
 }
 
+// providerEnvDefaults maps provider attributes to the environment
+// variables used when the attribute is not set in config.
+var providerEnvDefaults = []struct {
+	attribute string
+	env       string
+	required  bool
+}{
+	{"client_id", "ONELOGIN_CLIENT_ID", true},
+	{"client_secret", "ONELOGIN_CLIENT_SECRET", true},
+	{"subdomain", "ONELOGIN_SUBDOMAIN", true},
+	{"region", "ONELOGIN_REGION", false},
+}
+
+// withEnvDefaults fills attributes that are not set in config from the
+// environment.  Values set in config take precedence over the environment.
+func (data *oneLoginProviderModel) withEnvDefaults(getenv func(string) string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	values := map[string]*types.String{
+		"client_id":     &data.ClientID,
+		"client_secret": &data.CLientSecret,
+		"subdomain":     &data.Subdomain,
+		"region":        &data.Region,
+	}
+
+	for _, d := range providerEnvDefaults {
+		value := values[d.attribute]
+
+		// Unknown values can't fall back to the environment, they will
+		// be set by a resource that hasn't been applied yet.
+		if value.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root(d.attribute),
+				"Unknown OneLogin "+d.attribute,
+				fmt.Sprintf("The provider cannot create the OneLogin client as there is an unknown configuration value for %s. "+
+					"Either set the value statically in the configuration or use the %s environment variable.", d.attribute, d.env),
+			)
+			continue
+		}
+
+		if value.IsNull() || value.ValueString() == "" {
+			*value = types.StringNull()
+			if env := getenv(d.env); env != "" {
+				*value = types.StringValue(env)
+			}
+		}
+
+		if d.required && value.IsNull() {
+			diags.AddAttributeError(
+				path.Root(d.attribute),
+				"Missing OneLogin "+d.attribute,
+				fmt.Sprintf("The provider cannot create the OneLogin client as %s is not set. "+
+					"Set %s in the provider configuration or use the %s environment variable.", d.attribute, d.attribute, d.env),
+			)
+		}
+	}
+
+	return diags
+}
+
+// applyTo sets the values of the client block on the client config.
+// Unset values keep the defaults of the config.
+func (m *oneLoginProviderClientModel) applyTo(config *onelogin.ClientConfig) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	clientPath := path.Root("client")
+
+	duration := func(attribute string, value types.String, target *time.Duration) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		d, err := time.ParseDuration(value.ValueString())
+		if err == nil && d <= 0 {
+			err = fmt.Errorf("must be greater than 0")
+		}
+		if err != nil {
+			diags.AddAttributeError(clientPath.AtName(attribute), "Invalid "+attribute, fmt.Sprintf("%q is not a valid duration: %s", value.ValueString(), err))
+			return
+		}
+		*target = d
+	}
+
+	count := func(attribute string, value types.Int64, target *int) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		if value.ValueInt64() < 0 {
+			diags.AddAttributeError(clientPath.AtName(attribute), "Invalid "+attribute, "must not be negative")
+			return
+		}
+		*target = int(value.ValueInt64())
+	}
+
+	duration("request_timeout", m.RequestTimeout, &config.Timeout)
+	duration("retry_wait", m.RetryWait, &config.RetryWait)
+	count("max_retries", m.MaxRetries, &config.MaxRetries)
+	count("max_concurrent_requests", m.MaxConcurrentRequests, &config.MaxConcurrentRequests)
+	config.HTTPTrace = m.HTTPTrace.ValueBool()
+
+	return diags
+}
+
 func (p *oneloginProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewOneLoginRoleResource(&p.client),
@@ -121,12 +277,38 @@ func (p *oneloginProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewOneLoginUserResource(&p.client),
 		NewOneLoginMappingResource(&p.client),
 		NewOneLoginMappingOrderResource(&p.client),
+		NewOneLoginAPIAuthorizationResource(&p.client),
+		NewOneLoginAPIAuthorizationScopeResource(&p.client),
+		NewOneLoginAPIAuthorizationClaimResource(&p.client),
+		NewOneLoginAPIAuthorizationClientResource(&p.client),
+		NewOneLoginSmartHookResource(&p.client),
+		NewOneLoginSmartHookEnvVarResource(&p.client),
+		NewOneLoginBrandResource(&p.client),
+		NewOneLoginBrandTemplateResource(&p.client),
+		NewOneLoginTrustedIDPResource(&p.client),
+		NewOneLoginRiskRuleResource(&p.client),
+		NewOneLoginSelfRegistrationProfileResource(&p.client),
+		NewOneLoginPrivilegeResource(&p.client),
+		NewOneLoginPrivilegeRolesResource(&p.client),
+		NewOneLoginPrivilegeUsersResource(&p.client),
+		NewOneLoginUserMFADeviceResource(&p.client),
 	}
 }
 
 func (p *oneloginProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewOneLoginUserDataSource(&p.client),
+		NewOneLoginMappingOrderDataSource(&p.client),
+		NewOneLoginMappingDataSource(&p.client),
+		NewOneLoginMappingsDataSource(&p.client),
+		NewOneLoginSmartHookLogsDataSource(&p.client),
+		NewOneLoginRiskScoresDataSource(&p.client),
+		NewOneLoginGroupDataSource(&p.client),
+		NewOneLoginGroupsDataSource(&p.client),
+		NewOneLoginPolicyDataSource(&p.client),
+		NewOneLoginPoliciesDataSource(&p.client),
+		NewOneLoginAuthFactorsDataSource(&p.client),
+		NewOneLoginUserMFADevicesDataSource(&p.client),
 	}
 }
 
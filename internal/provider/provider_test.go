@@ -2,16 +2,22 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/ghaggin/terraform-provider-onelogin/onelogin"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -22,7 +28,10 @@ var (
 	// CLI command executed to create a provider server to which the CLI can
 	// reattach.
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-		"onelogin": providerserver.NewProtocol6WithError(New("test")()),
+		"onelogin": providerserver.NewProtocol6WithError(&oneloginProvider{
+			version:   "test",
+			transport: testTransport,
+		}),
 	}
 )
 
@@ -47,6 +56,12 @@ type providerTestSuite struct {
 
 	client         *onelogin.Client
 	providerConfig string
+
+	// rand generates the random names of the current test
+	rand *rand.Rand
+
+	// ejectCassette saves the cassette of the current test
+	ejectCassette func() error
 }
 
 func TestProvider(t *testing.T) {
@@ -54,13 +69,28 @@ func TestProvider(t *testing.T) {
 	clientSecret := os.Getenv("CLIENT_SECRET")
 	subdomain := os.Getenv("SUBDOMAIN")
 
+	if cassetteMode() == onelogin.CassetteReplay {
+		if _, err := os.Stat(cassettePath(t.Name())); errors.Is(err, os.ErrNotExist) {
+			t.Skipf("no cassettes are recorded in %s, record them with %s=record", cassetteDir, cassetteEnv)
+		}
+
+		// Replays don't need credentials, but the provider requires them
+		if clientID == "" {
+			clientID, clientSecret, subdomain = "replay", "replay", "replay"
+		}
+	}
+
+	eject, err := testTransport.use(t.Name())
+	require.NoError(t, err)
 	client, err := onelogin.NewClient(&onelogin.ClientConfig{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		Subdomain:    subdomain,
 		Timeout:      60 * time.Second,
+		Transport:    testTransport,
 	})
 	require.NoError(t, err)
+	require.NoError(t, eject())
 
 	testSuite := &providerTestSuite{
 		client: client,
@@ -77,8 +107,104 @@ func TestProvider(t *testing.T) {
 }
 
 func (s *providerTestSuite) SetupTest() {
+	s.rand = newTestRand(s.T().Name())
+	s.ejectCassette = func() error { return nil }
+
+	// Tests are replayed as their cassettes are recorded
+	if cassetteMode() == onelogin.CassetteReplay {
+		if _, err := os.Stat(cassettePath(s.T().Name())); errors.Is(err, os.ErrNotExist) {
+			s.T().Skipf("no cassette is recorded for %s, record it with %s=record", s.T().Name(), cassetteEnv)
+		}
+	}
+
+	eject, err := testTransport.use(s.T().Name())
+	s.Require().NoError(err)
+	s.ejectCassette = eject
+}
+
+func (s *providerTestSuite) TearDownTest() {
+	s.Require().NoError(s.ejectCassette())
 }
 
 func (s *providerTestSuite) randString() string {
-	return acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	b := make([]byte, 5)
+	for i := range b {
+		b[i] = acctest.CharSetAlphaNum[s.rand.Intn(len(acctest.CharSetAlphaNum))]
+	}
+	return string(b)
+}
+
+// childImportIDFunc returns the <parent_id>/<id> import id of a resource
+// that belongs to a parent object.
+func childImportIDFunc(resourceName, parentAttr, idAttr string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		return rs.Primary.Attributes[parentAttr] + "/" + rs.Primary.Attributes[idAttr], nil
+	}
+}
+
+func TestWithEnvDefaults(t *testing.T) {
+	env := map[string]string{
+		"ONELOGIN_CLIENT_ID":     "env_client_id",
+		"ONELOGIN_CLIENT_SECRET": "env_client_secret",
+		"ONELOGIN_SUBDOMAIN":     "env_subdomain",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	// Config takes precedence over the environment
+	data := oneLoginProviderModel{
+		ClientID:     types.StringValue("config_client_id"),
+		CLientSecret: types.StringNull(),
+		Subdomain:    types.StringValue(""),
+		Region:       types.StringNull(),
+	}
+	diags := data.withEnvDefaults(getenv)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, "config_client_id", data.ClientID.ValueString())
+	assert.Equal(t, "env_client_secret", data.CLientSecret.ValueString())
+	assert.Equal(t, "env_subdomain", data.Subdomain.ValueString())
+	assert.True(t, data.Region.IsNull())
+
+	// Missing values are reported against the attribute
+	data = oneLoginProviderModel{
+		ClientID:     types.StringNull(),
+		CLientSecret: types.StringUnknown(),
+		Subdomain:    types.StringNull(),
+		Region:       types.StringNull(),
+	}
+	diags = data.withEnvDefaults(func(string) string { return "" })
+	require.Len(t, diags.Errors(), 3)
+	assert.Equal(t, "Missing OneLogin client_id", diags.Errors()[0].Summary())
+	assert.Equal(t, "Unknown OneLogin client_secret", diags.Errors()[1].Summary())
+	assert.Equal(t, "Missing OneLogin subdomain", diags.Errors()[2].Summary())
+}
+
+func TestClientApplyTo(t *testing.T) {
+	config := &onelogin.ClientConfig{Timeout: time.Minute}
+	diags := (&oneLoginProviderClientModel{
+		RequestTimeout:        types.StringValue("90s"),
+		MaxRetries:            types.Int64Value(3),
+		RetryWait:             types.StringNull(),
+		MaxConcurrentRequests: types.Int64Value(4),
+		HTTPTrace:             types.BoolValue(true),
+	}).applyTo(config)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, 90*time.Second, config.Timeout)
+	assert.Equal(t, 3, config.MaxRetries)
+	assert.Equal(t, time.Duration(0), config.RetryWait)
+	assert.Equal(t, 4, config.MaxConcurrentRequests)
+	assert.True(t, config.HTTPTrace)
+
+	diags = (&oneLoginProviderClientModel{
+		RequestTimeout:        types.StringValue("soon"),
+		MaxRetries:            types.Int64Value(-1),
+		RetryWait:             types.StringValue("0s"),
+		MaxConcurrentRequests: types.Int64Null(),
+		HTTPTrace:             types.BoolNull(),
+	}).applyTo(config)
+	assert.Len(t, diags.Errors(), 3)
+	assert.Equal(t, 90*time.Second, config.Timeout)
 }
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// reconcileTimeout bounds the requests made to recover from a failed
+// create.  They run after the create context expired, so they get their
+// own deadline.
+const reconcileTimeout = 2 * time.Minute
+
+// reconcileContext returns a context for the requests that recover from a
+// failed create.  The values of ctx are kept but not its deadline.
+func reconcileContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), reconcileTimeout)
+}
+
+func adoptExistingAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Adopt an existing %[1]s with the same name on create instead of creating a new %[1]s. "+
+			"The %[1]s is updated to match the configuration. Fails if more than one %[1]s has the name.", kind),
+		Optional: true,
+	}
+}
+
+// reconcilePollInterval is the wait between lookups of an object that a
+// failed create may have created.  OneLogin can take a while to finish
+// such creates, the object often only shows up after a few lookups.
+var reconcilePollInterval = 5 * time.Second
+
+// createReconciler identifies the object created by a create request that
+// failed but may have succeeded.  Objects are matched by name as it is the
+// only identifier known before the object exists.  Names are not unique,
+// so the ids of the objects that have the name before the create are
+// collected and never adopted by reconciliation.
+type createReconciler struct {
+	kind   string
+	name   string
+	lookup importLookupFunc
+
+	existing    []int64
+	existingErr error
+
+	// collected is set once existing is looked up, afterFailure if that
+	// was only done after the create failed
+	collected    bool
+	afterFailure bool
+}
+
+func newCreateReconciler(kind, name string, lookup importLookupFunc) *createReconciler {
+	return &createReconciler{
+		kind:   kind,
+		name:   name,
+		lookup: lookup,
+	}
+}
+
+// collect looks up the objects with the name.  It is called before the
+// create request when an existing object may be adopted, creates that
+// don't adopt skip the lookup unless the create fails.
+func (r *createReconciler) collect(ctx context.Context) {
+	r.existing, r.existingErr = r.lookup(ctx, r.name)
+	r.collected = true
+}
+
+// adoptable returns the id of the only object that has the name before
+// the create, or 0 if there is no such object.
+func (r *createReconciler) adoptable() (int64, error) {
+	if r.existingErr != nil {
+		return 0, r.existingErr
+	}
+	return onlyID(r.kind, r.name, r.existing)
+}
+
+// created polls for an object with the name that did not exist before the
+// create until ctx is done.  An error is returned if no such object shows
+// up, or if it can't be told apart from other new objects with the name.
+//
+// If the objects were not collected before the create, the objects that
+// have the name right after the failure are taken as existing.  A create
+// that is already visible then is not adopted, the error names it as a
+// possible orphan instead.
+func (r *createReconciler) created(ctx context.Context) (int64, error) {
+	if !r.collected {
+		r.collect(ctx)
+		r.afterFailure = true
+	}
+	if r.existingErr != nil {
+		return 0, fmt.Errorf("the existing %ss named %q are unknown: %w", r.kind, r.name, r.existingErr)
+	}
+
+	for {
+		ids, err := r.lookup(ctx, r.name)
+		if err != nil {
+			return 0, err
+		}
+
+		id, err := onlyID(r.kind, r.name, newIDs(r.existing, ids))
+		if err != nil || id != 0 {
+			return id, err
+		}
+
+		select {
+		case <-ctx.Done():
+			if r.afterFailure && len(r.existing) > 0 {
+				return 0, fmt.Errorf("no new %[1]s named %[2]q was found, the %[1]s may be one of the %[1]ss that had the name when the create failed: %[3]s: %[4]w",
+					r.kind, r.name, joinIDs(r.existing), ctx.Err())
+			}
+			return 0, fmt.Errorf("no new %s named %q was found: %w", r.kind, r.name, ctx.Err())
+		case <-time.After(reconcilePollInterval):
+		}
+	}
+}
+
+// onlyID returns the id if there is exactly one, or 0 if there is none
+func onlyID(kind, name string, ids []int64) (int64, error) {
+	switch len(ids) {
+	case 0:
+		return 0, nil
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, fmt.Errorf("found %d %ss named %q: %s", len(ids), kind, name, joinIDs(ids))
+	}
+}
+
+func joinIDs(ids []int64) string {
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(idStrings, ", ")
+}
+
+// newIDs returns the ids that are not in existing
+func newIDs(existing, ids []int64) []int64 {
+	added := []int64{}
+	for _, id := range ids {
+		if !containsIDs(existing, []int64{id}) {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// orphanDetail explains a create failure that may have left an object in
+// OneLogin which is not tracked in the state.
+func orphanDetail(kind, name string, createErr, lookupErr error) string {
+	return fmt.Sprintf("Could not create %[1]s: %[3]s\n\n"+
+		"The %[1]s may have been created in OneLogin, but it could not be identified: %[4]s\n\n"+
+		"Import the %[1]s named %[2]q or delete it before applying again, otherwise a duplicate %[1]s is created.",
+		kind, name, createErr, lookupErr)
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateReconcilerAdoptable(t *testing.T) {
+	lookup := func(_ context.Context, name string) ([]int64, error) {
+		switch name {
+		case "one":
+			return []int64{1}, nil
+		case "two":
+			return []int64{1, 2}, nil
+		case "error":
+			return nil, fmt.Errorf("lookup failed")
+		default:
+			return []int64{}, nil
+		}
+	}
+	ctx := context.Background()
+	adoptable := func(name string) (int64, error) {
+		r := newCreateReconciler("role", name, lookup)
+		r.collect(ctx)
+		return r.adoptable()
+	}
+
+	id, err := adoptable("one")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+
+	id, err = adoptable("none")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), id)
+
+	_, err = adoptable("two")
+	assert.ErrorContains(t, err, `found 2 roles named "two": 1, 2`)
+
+	_, err = adoptable("error")
+	assert.ErrorContains(t, err, "lookup failed")
+}
+
+func TestCreateReconcilerCreated(t *testing.T) {
+	defer func(interval time.Duration) { reconcilePollInterval = interval }(reconcilePollInterval)
+	reconcilePollInterval = time.Millisecond
+	ctx := context.Background()
+
+	// The app created by the failed request shows up on the third lookup,
+	// the app that had the name before is never adopted.
+	lookups := 0
+	r := newCreateReconciler("app", "test", func(context.Context, string) ([]int64, error) {
+		lookups++
+		if lookups < 3 {
+			return []int64{7}, nil
+		}
+		return []int64{7, 8}, nil
+	})
+	assert.Equal(t, 0, lookups)
+	r.collect(ctx)
+	id, err := r.created(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), id)
+	assert.Equal(t, 3, lookups)
+
+	// Without a lookup before the create, the apps are looked up once the
+	// create failed
+	lookups = 0
+	r = newCreateReconciler("app", "test", func(context.Context, string) ([]int64, error) {
+		lookups++
+		if lookups < 2 {
+			return []int64{}, nil
+		}
+		return []int64{8}, nil
+	})
+	id, err = r.created(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), id)
+	assert.Equal(t, 2, lookups)
+
+	// Nothing shows up before the context is done
+	r = newCreateReconciler("app", "test", func(context.Context, string) ([]int64, error) {
+		return []int64{7}, nil
+	})
+	r.collect(ctx)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = r.created(timeoutCtx)
+	assert.ErrorContains(t, err, `no new app named "test" was found`)
+	assert.NotContains(t, err.Error(), "may be one of")
+
+	// An app that is visible as soon as the create failed may be the
+	// created app, it is named but not adopted
+	r = newCreateReconciler("app", "test", func(context.Context, string) ([]int64, error) {
+		return []int64{7}, nil
+	})
+	timeoutCtx, cancel = context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = r.created(timeoutCtx)
+	assert.ErrorContains(t, err, `no new app named "test" was found, the app may be one of the apps that had the name when the create failed: 7`)
+
+	// New apps that can't be told apart
+	lookups = 0
+	r = newCreateReconciler("app", "test", func(context.Context, string) ([]int64, error) {
+		lookups++
+		if lookups == 1 {
+			return []int64{}, nil
+		}
+		return []int64{8, 9}, nil
+	})
+	_, err = r.created(ctx)
+	assert.ErrorContains(t, err, `found 2 apps named "test": 8, 9`)
+
+	// Existing apps must be known to reconcile
+	r = newCreateReconciler("app", "test", func(context.Context, string) ([]int64, error) {
+		return nil, fmt.Errorf("lookup failed")
+	})
+	_, err = r.created(ctx)
+	assert.ErrorContains(t, err, "lookup failed")
+}
+
+func TestReconcileContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reconcileCtx, reconcileCancel := reconcileContext(ctx)
+	defer reconcileCancel()
+	assert.NoError(t, reconcileCtx.Err())
+	_, ok := reconcileCtx.Deadline()
+	assert.True(t, ok)
+}
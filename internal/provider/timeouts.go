@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Operations of a resource that can have a timeout
+const (
+	timeoutCreate = "create"
+	timeoutRead   = "read"
+	timeoutUpdate = "update"
+	timeoutDelete = "delete"
+)
+
+func timeoutsTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		timeoutCreate: types.StringType,
+		timeoutRead:   types.StringType,
+		timeoutUpdate: types.StringType,
+		timeoutDelete: types.StringType,
+	}
+}
+
+func timeoutsNull() types.Object {
+	return types.ObjectNull(timeoutsTypes())
+}
+
+// timeoutsBlock is the timeouts block of resources with slow operations.
+// Each operation is limited to its timeout, including retries.  Without
+// a timeout only the client request timeout applies to each request.
+func timeoutsBlock() schema.Block {
+	attributes := map[string]schema.Attribute{}
+	for operation := range timeoutsTypes() {
+		attributes[operation] = schema.StringAttribute{
+			MarkdownDescription: "Timeout of the " + operation + " operation as a duration, e.g. `5m`. " +
+				"Also extends the request timeout of the provider for the operation.",
+			Optional: true,
+			Validators: []validator.String{
+				stringIsDuration(),
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Attributes: attributes,
+	}
+}
+
+// timeoutContext returns a context with the deadline of the operation
+// timeout.  The context has no deadline if the timeout is not set.
+func timeoutContext(ctx context.Context, timeouts types.Object, operation string, d *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return context.WithCancel(ctx)
+	}
+
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return context.WithCancel(ctx)
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		d.AddError("Invalid timeout", "Could not parse "+operation+" timeout: "+err.Error())
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutContext(t *testing.T) {
+	ctx := context.Background()
+	timeouts := types.ObjectValueMust(timeoutsTypes(), map[string]attr.Value{
+		timeoutCreate: types.StringValue("5m"),
+		timeoutRead:   types.StringNull(),
+		timeoutUpdate: types.StringNull(),
+		timeoutDelete: types.StringNull(),
+	})
+
+	d := diag.Diagnostics{}
+	opCtx, cancel := timeoutContext(ctx, timeouts, timeoutCreate, &d)
+	defer cancel()
+	require.False(t, d.HasError(), d.Errors())
+	deadline, ok := opCtx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), deadline, time.Second)
+
+	// Unset operations and blocks have no deadline
+	opCtx, cancel = timeoutContext(ctx, timeouts, timeoutRead, &d)
+	defer cancel()
+	_, ok = opCtx.Deadline()
+	assert.False(t, ok)
+
+	opCtx, cancel = timeoutContext(ctx, timeoutsNull(), timeoutDelete, &d)
+	defer cancel()
+	_, ok = opCtx.Deadline()
+	assert.False(t, ok)
+	assert.False(t, d.HasError())
+}
+
+func TestStringIsDuration(t *testing.T) {
+	ctx := context.Background()
+	v := stringIsDuration()
+
+	validate := func(value types.String) bool {
+		resp := &validator.StringResponse{}
+		v.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("timeouts").AtName("create"),
+			ConfigValue: value,
+		}, resp)
+		return !resp.Diagnostics.HasError()
+	}
+
+	assert.True(t, validate(types.StringValue("90s")))
+	assert.True(t, validate(types.StringValue("1h30m")))
+	assert.True(t, validate(types.StringNull()))
+	assert.False(t, validate(types.StringValue("0s")))
+	assert.False(t, validate(types.StringValue("5 minutes")))
+}
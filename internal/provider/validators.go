@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.String = stringOneOfValidator{}
+
+// stringOneOfValidator rejects string values not in a fixed set
+type stringOneOfValidator struct {
+	values []string
+}
+
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !slices.Contains(v.values, value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid attribute value",
+			fmt.Sprintf("%q is not valid, %s", value, v.Description(ctx)),
+		)
+	}
+}
+
+var _ validator.List = listValuesOneOfValidator{}
+
+// listValuesOneOfValidator rejects lists of strings containing values not in
+// a fixed set
+type listValuesOneOfValidator struct {
+	values stringOneOfValidator
+}
+
+func listValuesOneOf(values ...string) validator.List {
+	return listValuesOneOfValidator{values: stringOneOfValidator{values: values}}
+}
+
+func (v listValuesOneOfValidator) Description(ctx context.Context) string {
+	return "each " + v.values.Description(ctx)
+}
+
+func (v listValuesOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v listValuesOneOfValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok {
+			continue
+		}
+
+		elementResp := &validator.StringResponse{}
+		v.values.ValidateString(ctx, validator.StringRequest{
+			Path:        req.Path.AtListIndex(i),
+			ConfigValue: value,
+		}, elementResp)
+		resp.Diagnostics.Append(elementResp.Diagnostics...)
+	}
+}
+
+var _ validator.String = stringIsDurationValidator{}
+
+// stringIsDurationValidator rejects strings that are not a positive
+// duration, e.g. 90s or 5m
+type stringIsDurationValidator struct{}
+
+func stringIsDuration() validator.String {
+	return stringIsDurationValidator{}
+}
+
+func (v stringIsDurationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration, e.g. 90s or 5m"
+}
+
+func (v stringIsDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid attribute value",
+			fmt.Sprintf("%q is not valid, %s", value, v.Description(ctx)),
+		)
+	}
+}
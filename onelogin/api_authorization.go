@@ -0,0 +1,41 @@
+package onelogin
+
+// https://developers.onelogin.com/api-docs/2/api-authorization/overview
+type APIAuthorization struct {
+	ID            int64                          `json:"id,omitempty"`
+	Name          string                         `json:"name"`
+	Description   string                         `json:"description"`
+	Configuration *APIAuthorizationConfiguration `json:"configuration,omitempty"`
+}
+
+type APIAuthorizationConfiguration struct {
+	ResourceIdentifier            string   `json:"resource_identifier"`
+	Audiences                     []string `json:"audiences,omitempty"`
+	AccessTokenExpirationMinutes  int64    `json:"access_token_expiration_minutes,omitempty"`
+	RefreshTokenExpirationMinutes int64    `json:"refresh_token_expiration_minutes,omitempty"`
+}
+
+type APIAuthorizationScope struct {
+	ID          int64  `json:"id,omitempty"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+type APIAuthorizationClaim struct {
+	ID                    int64  `json:"id,omitempty"`
+	Name                  string `json:"name"`
+	UserAttributeMappings string `json:"user_attribute_mappings,omitempty"`
+	UserAttributeMacros   string `json:"user_attribute_macros,omitempty"`
+}
+
+// APIAuthorizationClient is an app granted access to an api authorization.
+// Scopes are returned as objects and sent as ids.
+type APIAuthorizationClient struct {
+	AppID  int64                   `json:"app_id"`
+	Scopes []APIAuthorizationScope `json:"scopes"`
+}
+
+type APIAuthorizationClientRequest struct {
+	AppID  int64   `json:"app_id,omitempty"`
+	Scopes []int64 `json:"scopes"`
+}
@@ -0,0 +1,78 @@
+package onelogin
+
+import "encoding/json"
+
+// https://developers.onelogin.com/api-docs/2/branding/overview
+type Brand struct {
+	ID                              int64  `json:"id,omitempty"`
+	Name                            string `json:"name"`
+	Enabled                         bool   `json:"enabled"`
+	CustomSupportEnabled            bool   `json:"custom_support_enabled"`
+	CustomColor                     string `json:"custom_color"`
+	CustomAccentColor               string `json:"custom_accent_color"`
+	CustomMaskingColor              string `json:"custom_masking_color"`
+	CustomMaskingOpacity            int64  `json:"custom_masking_opacity"`
+	EnableCustomLabelForLoginScreen bool   `json:"enable_custom_label_for_login_screen"`
+	CustomLabelTextForLoginScreen   string `json:"custom_label_text_for_login_screen"`
+	LoginInstructionTitle           string `json:"login_instruction_title"`
+	LoginInstruction                string `json:"login_instruction"`
+	HideOneLoginFooter              bool   `json:"hide_onelogin_footer"`
+	MFAEnrollmentMessage            string `json:"mfa_enrollment_message"`
+
+	Background *BrandImage `json:"background,omitempty"`
+	Logo       *BrandImage `json:"logo,omitempty"`
+}
+
+type BrandImage struct {
+	OriginalURL string `json:"original_url"`
+}
+
+// BrandRequest is the body of create and update requests.  Images are sent
+// base64 encoded and are left unchanged when empty.
+type BrandRequest struct {
+	Brand
+
+	Background string `json:"background,omitempty"`
+	Logo       string `json:"logo,omitempty"`
+
+	// ClearBackground and ClearLogo remove the images, they are sent as null
+	ClearBackground bool `json:"-"`
+	ClearLogo       bool `json:"-"`
+}
+
+func (r BrandRequest) MarshalJSON() ([]byte, error) {
+	type brandRequest BrandRequest
+	b, err := json.Marshal(brandRequest(r))
+	if err != nil || (!r.ClearBackground && !r.ClearLogo) {
+		return b, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if r.ClearBackground {
+		fields["background"] = json.RawMessage("null")
+	}
+	if r.ClearLogo {
+		fields["logo"] = json.RawMessage("null")
+	}
+	return json.Marshal(fields)
+}
+
+// BrandTemplate is an email or sms message template of a brand
+type BrandTemplate struct {
+	ID       int64                `json:"id,omitempty"`
+	Type     string               `json:"type"`
+	Locale   string               `json:"locale"`
+	Template BrandTemplateContent `json:"template"`
+}
+
+// BrandTemplateContent uses subject, html and plain for email templates
+// and message for sms templates
+type BrandTemplateContent struct {
+	Subject string `json:"subject,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	Plain   string `json:"plain,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,29 @@
+package onelogin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrandRequestJSON(t *testing.T) {
+	b, err := json.Marshal(&BrandRequest{Brand: Brand{Name: "test"}, Logo: "bG9nbw=="})
+	require.NoError(t, err)
+	fields := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, "test", fields["name"])
+	assert.Equal(t, "bG9nbw==", fields["logo"])
+	assert.NotContains(t, fields, "background")
+	assert.NotContains(t, fields, "ClearLogo")
+
+	// Cleared images are sent as null
+	b, err = json.Marshal(&BrandRequest{Brand: Brand{Name: "test"}, ClearBackground: true})
+	require.NoError(t, err)
+	fields = map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(b, &fields))
+	assert.Contains(t, fields, "background")
+	assert.Nil(t, fields["background"])
+	assert.NotContains(t, fields, "logo")
+}
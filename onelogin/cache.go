@@ -0,0 +1,215 @@
+package onelogin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	roleIDPath    = regexp.MustCompile(`^` + PathRoles + `/([0-9]+)$`)
+	mappingIDPath = regexp.MustCompile(`^` + PathMappings + `/([0-9]+)$`)
+)
+
+// roleCacheFields are requested when listing roles so that
+// the listed roles match the roles returned by GET /roles/{id}
+const roleCacheFields = "id,name,admins,apps,users"
+
+// readCache serves reads of individual roles and mappings, and the enabled and
+// disabled mapping lists, from a single list request for each collection.
+//
+// Collections are listed on the first read after the cache is created or
+// invalidated.  Any write request invalidates the whole cache because writes
+// to one collection can change objects in another, e.g. deleting a user
+// removes it from every role.  Reads that can not be served from a loaded
+// collection, e.g. an object created after it was listed, are sent to OneLogin.
+type readCache struct {
+	mu sync.Mutex
+
+	// roles is nil until roles are listed
+	roles map[int64]json.RawMessage
+
+	// mappings is nil until mappings are listed.  Mappings are kept in the
+	// order returned by OneLogin for enabled=true and enabled=false.
+	mappings     map[bool][]json.RawMessage
+	mappingsByID map[int64]json.RawMessage
+}
+
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.roles = nil
+	rc.mappings = nil
+	rc.mappingsByID = nil
+}
+
+// cachedRead serves req from the read cache.  ok is false if
+// req can not be served from the cache and should be sent to OneLogin.
+func (c *Client) cachedRead(req *Request) (ok bool, err error) {
+	if req.Context == nil {
+		req.Context = context.Background()
+	}
+
+	params, isQueryParams := req.QueryParams.(QueryParams)
+	if req.QueryParams != nil && !isQueryParams {
+		return false, nil
+	}
+
+	var raw []byte
+	switch {
+	case len(params) == 0 && roleIDPath.MatchString(req.Path):
+		id, _ := strconv.ParseInt(roleIDPath.FindStringSubmatch(req.Path)[1], 10, 64)
+		raw, ok, err = c.cache.role(c, req.Context, id)
+
+	case len(params) == 0 && mappingIDPath.MatchString(req.Path):
+		id, _ := strconv.ParseInt(mappingIDPath.FindStringSubmatch(req.Path)[1], 10, 64)
+		raw, ok, err = c.cache.mapping(c, req.Context, id)
+
+	case req.Path == PathMappings:
+		enabled := true
+		for k, v := range params {
+			if k != "enabled" {
+				return false, nil
+			}
+			enabled, err = strconv.ParseBool(fmt.Sprint(v))
+			if err != nil {
+				return false, nil
+			}
+		}
+		raw, ok, err = c.cache.mappingList(c, req.Context, enabled)
+
+	default:
+		return false, nil
+	}
+
+	if err != nil {
+		// Fall back to the request if the collection can not be listed
+		c.log.Warn(req.Context, "unable to load read cache", map[string]interface{}{
+			"method": req.Method,
+			"path":   req.Path,
+			"error":  err.Error(),
+		})
+		return false, nil
+	}
+	if !ok {
+		return false, nil
+	}
+
+	c.log.Info(req.Context, "request served from cache", map[string]interface{}{
+		"method": req.Method,
+		"path":   req.Path,
+	})
+
+	if req.RespModel != nil {
+		return true, json.Unmarshal(raw, req.RespModel)
+	}
+	return true, nil
+}
+
+func (rc *readCache) role(c *Client, ctx context.Context, id int64) ([]byte, bool, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.roles == nil {
+		roles, err := ListAll[json.RawMessage](c, &Request{
+			Context:     ctx,
+			Method:      MethodGet,
+			Path:        PathRoles,
+			QueryParams: QueryParams{"fields": roleCacheFields},
+			Retry:       3,
+			RetryWait:   time.Second,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		rc.roles, err = indexByID(roles)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	raw, ok := rc.roles[id]
+	return raw, ok, nil
+}
+
+func (rc *readCache) mapping(c *Client, ctx context.Context, id int64) ([]byte, bool, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	err := rc.loadMappings(c, ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	raw, ok := rc.mappingsByID[id]
+	return raw, ok, nil
+}
+
+func (rc *readCache) mappingList(c *Client, ctx context.Context, enabled bool) ([]byte, bool, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	err := rc.loadMappings(c, ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	raw, err := json.Marshal(rc.mappings[enabled])
+	return raw, err == nil, err
+}
+
+// loadMappings lists enabled and disabled mappings if they are not loaded.
+// rc.mu must be held.
+func (rc *readCache) loadMappings(c *Client, ctx context.Context) error {
+	if rc.mappings != nil {
+		return nil
+	}
+
+	mappings := map[bool][]json.RawMessage{}
+	all := []json.RawMessage{}
+	for _, enabled := range []bool{true, false} {
+		list := []json.RawMessage{}
+		err := c.execRequest(&Request{
+			Context:     ctx,
+			Method:      MethodGet,
+			Path:        PathMappings,
+			QueryParams: QueryParams{"enabled": strconv.FormatBool(enabled)},
+			RespModel:   &list,
+		})
+		if err != nil {
+			return err
+		}
+		mappings[enabled] = list
+		all = append(all, list...)
+	}
+
+	byID, err := indexByID(all)
+	if err != nil {
+		return err
+	}
+
+	rc.mappings = mappings
+	rc.mappingsByID = byID
+	return nil
+}
+
+func indexByID(objects []json.RawMessage) (map[int64]json.RawMessage, error) {
+	byID := make(map[int64]json.RawMessage, len(objects))
+	for _, raw := range objects {
+		var obj struct {
+			ID int64 `json:"id"`
+		}
+		err := json.Unmarshal(raw, &obj)
+		if err != nil {
+			return nil, err
+		}
+		byID[obj.ID] = raw
+	}
+	return byID, nil
+}
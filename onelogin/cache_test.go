@@ -0,0 +1,141 @@
+package onelogin
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *clientTestSuite) registerCacheResponders() {
+	httpmock.ZeroCallCounters()
+
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles, func(req *http.Request) (*http.Response, error) {
+		s.Equal(roleCacheFields, req.URL.Query().Get("fields"))
+		resp, err := httpmock.NewJsonResponse(200, []Role{
+			{ID: 1, Name: "role_1", Users: []int64{10, 11}},
+			{ID: 2, Name: "role_2", Apps: []int64{20}},
+		})
+		s.Require().NoError(err)
+		resp.Header.Set("Total-Pages", "1")
+		return resp, nil
+	})
+
+	position := int64(1)
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathMappings, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("enabled") == "false" {
+			return httpmock.NewJsonResponse(200, []Mapping{{ID: 4, Name: "disabled"}})
+		}
+		return httpmock.NewJsonResponse(200, []Mapping{{ID: 3, Name: "enabled", Enabled: true, Position: &position}})
+	})
+
+	httpmock.RegisterRegexpResponder(string(MethodGet), regexp.MustCompile(`^https://test_subdomain.onelogin.com/api/2/(roles|mappings)/[0-9]+$`),
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{"id": 99, "name": "uncached"}))
+	httpmock.RegisterResponder(string(MethodPut), "https://test_subdomain.onelogin.com"+PathRoles+"/1",
+		httpmock.NewStringResponder(200, `{"id": 1}`))
+}
+
+func (s *clientTestSuite) Test_ReadCache() {
+	s.client.cache = &readCache{}
+	s.registerCacheResponders()
+
+	// Roles are listed once and served from the cache
+	for _, id := range []int64{1, 2} {
+		var role Role
+		err := s.client.ExecRequest(&Request{
+			Method:    MethodGet,
+			Path:      fmt.Sprintf("%s/%d", PathRoles, id),
+			RespModel: &role,
+		})
+		s.Require().NoError(err)
+		s.Equal(id, role.ID)
+	}
+	var role Role
+	err := s.client.ExecRequest(&Request{
+		Method:    MethodGet,
+		Path:      PathRoles + "/1",
+		RespModel: &role,
+	})
+	s.Require().NoError(err)
+	s.Equal([]int64{10, 11}, role.Users)
+
+	// Mappings and the enabled and disabled lists are served from one listing
+	var mapping Mapping
+	err = s.client.ExecRequest(&Request{
+		Method:    MethodGet,
+		Path:      PathMappings + "/4",
+		RespModel: &mapping,
+	})
+	s.Require().NoError(err)
+	s.Equal("disabled", mapping.Name)
+
+	var enabled []Mapping
+	err = s.client.ExecRequest(&Request{
+		Method:    MethodGet,
+		Path:      PathMappings,
+		RespModel: &enabled,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(enabled, 1)
+	s.Equal(int64(3), enabled[0].ID)
+
+	var disabled []Mapping
+	err = s.client.ExecRequest(&Request{
+		Method:      MethodGet,
+		Path:        PathMappings,
+		QueryParams: QueryParams{"enabled": "false"},
+		RespModel:   &disabled,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(disabled, 1)
+	s.Equal(int64(4), disabled[0].ID)
+
+	calls := httpmock.GetCallCountInfo()
+	s.Equal(1, calls["GET https://test_subdomain.onelogin.com"+PathRoles])
+	s.Equal(2, calls["GET https://test_subdomain.onelogin.com"+PathMappings])
+	s.Equal(0, calls["GET =~^https://test_subdomain.onelogin.com/api/2/(roles|mappings)/[0-9]+$"])
+
+	// Objects missing from the listing are read from OneLogin
+	err = s.client.ExecRequest(&Request{
+		Method:    MethodGet,
+		Path:      PathRoles + "/99",
+		RespModel: &role,
+	})
+	s.Require().NoError(err)
+	s.Equal("uncached", role.Name)
+
+	// Writes invalidate the cache
+	err = s.client.ExecRequest(&Request{
+		Method: MethodPut,
+		Path:   PathRoles + "/1",
+		Body:   Role{Name: "role_1"},
+	})
+	s.Require().NoError(err)
+	err = s.client.ExecRequest(&Request{
+		Method:    MethodGet,
+		Path:      PathRoles + "/1",
+		RespModel: &role,
+	})
+	s.Require().NoError(err)
+
+	calls = httpmock.GetCallCountInfo()
+	s.Equal(2, calls["GET https://test_subdomain.onelogin.com"+PathRoles])
+	s.Equal(1, calls["GET =~^https://test_subdomain.onelogin.com/api/2/(roles|mappings)/[0-9]+$"])
+}
+
+func (s *clientTestSuite) Test_ReadCacheDisabled() {
+	s.registerCacheResponders()
+
+	var role Role
+	err := s.client.ExecRequest(&Request{
+		Method:    MethodGet,
+		Path:      PathRoles + "/1",
+		RespModel: &role,
+	})
+	s.Require().NoError(err)
+	s.Equal("uncached", role.Name)
+
+	calls := httpmock.GetCallCountInfo()
+	s.Equal(0, calls["GET https://test_subdomain.onelogin.com"+PathRoles])
+}
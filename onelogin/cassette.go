@@ -0,0 +1,280 @@
+package onelogin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a cassette records or replays requests
+type CassetteMode string
+
+const (
+	// CassetteRecord sends requests to OneLogin and records the exchanges
+	CassetteRecord CassetteMode = "record"
+
+	// CassetteReplay answers requests from the recorded exchanges without
+	// sending them
+	CassetteReplay CassetteMode = "replay"
+)
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the part of a request that is matched on replay.  The
+// query is sorted and the body is compacted json with sorted keys.
+type CassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// pathToken is the path of token requests
+const pathToken = "/auth/oauth2/v2/token"
+
+type cassetteFile struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// CassetteTransport records the http exchanges of a live run to a file and
+// replays them offline, e.g. to run acceptance tests without credentials.
+// Set it as the Transport of the ClientConfig.
+//
+// Secrets are scrubbed from recorded requests and headers with the
+// DefaultRedactedKeys and the keys given to NewCassetteTransport, requests
+// are matched on replay after the same scrubbing.  Response bodies are
+// recorded as received so that a replay reads the same objects, only the
+// tokens of token requests are scrubbed.  Review cassettes before they are
+// committed.  The host is not recorded, so cassettes replay against any
+// subdomain.
+//
+// Requests are replayed by method, path, query and body.  Identical
+// requests are answered in the order they were recorded, once all of
+// them are used the last one is replayed again, e.g. for token refreshes
+// or additional polls.
+type CassetteTransport struct {
+	path     string
+	mode     CassetteMode
+	next     http.RoundTripper
+	redactor *redactor
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewCassetteTransport returns a transport that records to or replays from
+// the cassette at path.  In replay mode a missing cassette is empty, every
+// request then fails.  next sends recorded requests, nil uses the default
+// transport.
+func NewCassetteTransport(path string, mode CassetteMode, next http.RoundTripper, redactedKeys ...string) (*CassetteTransport, error) {
+	t := &CassetteTransport{
+		path:     path,
+		mode:     mode,
+		next:     next,
+		redactor: newRedactor(redactedKeys...),
+	}
+
+	switch mode {
+	case CassetteRecord:
+		return t, nil
+	case CassetteReplay:
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f cassetteFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	t.interactions = f.Interactions
+	t.used = make([]bool, len(f.Interactions))
+
+	return t, nil
+}
+
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := t.normalize(req, body)
+
+	if t.mode == CassetteReplay {
+		return t.replay(req, key)
+	}
+
+	if req.Body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := roundTrip(t.next, req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.record(&Interaction{
+		Request: key,
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    t.scrubHeaders(resp.Header),
+			Body:       t.responseBody(req, respBody),
+		},
+	})
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette.  Save does
+// nothing in replay mode.
+func (t *CassetteTransport) Save() error {
+	if t.mode != CassetteRecord {
+		return nil
+	}
+
+	t.mu.Lock()
+	b, err := json.MarshalIndent(&cassetteFile{Interactions: t.interactions}, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, append(b, '\n'), 0o644)
+}
+
+func (t *CassetteTransport) record(interaction *Interaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, interaction)
+}
+
+func (t *CassetteTransport) replay(req *http.Request, key CassetteRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last := -1
+	for i, interaction := range t.interactions {
+		if interaction.Request != key {
+			continue
+		}
+		if !t.used[i] {
+			t.used[i] = true
+			return interaction.Response.toHTTP(req), nil
+		}
+		last = i
+	}
+
+	if last < 0 {
+		return nil, fmt.Errorf("cassette %s has no interaction for %s %s", t.path, key.Method, key.Path)
+	}
+	return t.interactions[last].Response.toHTTP(req), nil
+}
+
+// normalize returns the scrubbed request as it is recorded and matched
+func (t *CassetteTransport) normalize(req *http.Request, body []byte) CassetteRequest {
+	query := req.URL.RawQuery
+	if values, err := url.ParseQuery(query); err == nil {
+		query = values.Encode()
+	}
+
+	return CassetteRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  query,
+		Body:   t.redactor.redactJSON(strings.TrimSpace(string(body))),
+	}
+}
+
+// responseBody returns the response body as it is recorded
+func (t *CassetteTransport) responseBody(req *http.Request, body []byte) string {
+	if req.URL.Path == pathToken {
+		return t.redactor.redactJSON(string(body))
+	}
+	return string(body)
+}
+
+// scrubHeaders drops cookies and redacts the headers of the redacted keys
+func (t *CassetteTransport) scrubHeaders(header http.Header) http.Header {
+	scrubbed := t.redactor.redact(header).(http.Header)
+	delete(scrubbed, "Set-Cookie")
+	return scrubbed
+}
+
+func (r *CassetteResponse) toHTTP(req *http.Request) *http.Response {
+	header := r.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads the body without consuming the body of the request
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
@@ -0,0 +1,113 @@
+package onelogin
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *clientTestSuite) Test_Cassette() {
+	path := filepath.Join(s.T().TempDir(), "cassettes", "roles.json")
+
+	type role struct {
+		ID     int64  `json:"id,omitempty"`
+		Name   string `json:"name,omitempty"`
+		Secret string `json:"secret,omitempty"`
+	}
+
+	reads := 0
+	httpmock.RegisterResponder(string(MethodPost), "https://test_subdomain.onelogin.com"+PathRoles, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(http.StatusCreated, `{"id":1}`), nil
+	})
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles+"/1", func(req *http.Request) (*http.Response, error) {
+		reads++
+		if reads == 1 {
+			return httpmock.NewStringResponse(http.StatusNotFound, ""), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{"id":1,"name":"test","secret":"hunter2"}`), nil
+	})
+
+	calls := func(c *Client) {
+		var created role
+		s.Require().NoError(c.ExecRequest(&Request{
+			Method:    MethodPost,
+			Path:      PathRoles,
+			Body:      map[string]interface{}{"name": "test", "client_secret": "hunter2"},
+			RespModel: &created,
+		}))
+		s.Equal(int64(1), created.ID)
+
+		// Identical requests are replayed in order, the last one is repeated
+		var read role
+		s.ErrorIs(c.ExecRequest(&Request{Method: MethodGet, Path: PathRoles + "/1", RespModel: &read}), ErrNotFound)
+		for i := 0; i < 2; i++ {
+			s.Require().NoError(c.ExecRequest(&Request{Method: MethodGet, Path: PathRoles + "/1", RespModel: &read}))
+			s.Equal("test", read.Name)
+			s.Equal("hunter2", read.Secret)
+		}
+	}
+
+	recorder, err := NewCassetteTransport(path, CassetteRecord, nil)
+	s.Require().NoError(err)
+	calls(s.newClient(&ClientConfig{Transport: recorder}))
+	s.Require().NoError(recorder.Save())
+	s.Equal(3, reads)
+
+	b, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.NotContains(string(b), "test_access_token")
+	s.NotContains(string(b), "test_subdomain")
+
+	// Requests are scrubbed, responses are recorded as received
+	var f cassetteFile
+	s.Require().NoError(json.Unmarshal(b, &f))
+	s.Require().Len(f.Interactions, 5)
+	s.Equal(`{"client_secret":"[REDACTED]","name":"test"}`, f.Interactions[1].Request.Body)
+	s.Equal(`{"id":1,"name":"test","secret":"hunter2"}`, f.Interactions[3].Response.Body)
+
+	// Replay without a tenant, on a different subdomain
+	httpmock.Reset()
+	player, err := NewCassetteTransport(path, CassetteReplay, nil)
+	s.Require().NoError(err)
+	c, err := NewClient(&ClientConfig{
+		ClientID:     "replay",
+		ClientSecret: "replay",
+		Subdomain:    "replay",
+		Transport:    player,
+	})
+	s.Require().NoError(err)
+	calls(c)
+	s.Equal(3, reads)
+	s.NoError(player.Save())
+
+	// Bodies are matched after normalization
+	err = c.ExecRequest(&Request{
+		Method: MethodPost,
+		Path:   PathRoles,
+		Body:   map[string]interface{}{"client_secret": "other", "name": "test"},
+	})
+	s.NoError(err)
+
+	err = c.ExecRequest(&Request{
+		Method: MethodPost,
+		Path:   PathRoles,
+		Body:   map[string]interface{}{"name": "other"},
+	})
+	s.ErrorContains(err, "no interaction for POST "+PathRoles)
+}
+
+func (s *clientTestSuite) Test_CassetteMode() {
+	_, err := NewCassetteTransport("cassette.json", CassetteMode("rewind"), nil)
+	s.Error(err)
+
+	// Missing cassettes are empty on replay
+	player, err := NewCassetteTransport(filepath.Join(s.T().TempDir(), "missing.json"), CassetteReplay, nil)
+	s.Require().NoError(err)
+	req, err := http.NewRequest(http.MethodGet, "https://test_subdomain.onelogin.com"+PathRoles, nil)
+	s.Require().NoError(err)
+	_, err = player.RoundTrip(req)
+	s.ErrorContains(err, "no interaction for GET "+PathRoles)
+}
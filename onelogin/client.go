@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -15,9 +16,28 @@ import (
 )
 
 const (
-	DefaultTimeout = 60 * time.Second
+	DefaultTimeout   = 60 * time.Second
+	DefaultRetryWait = time.Second
+
+	DefaultConsistencyTimeout = 30 * time.Second
 )
 
+// DefaultRetriableStatusCodes are retried for requests that don't set
+// their own retries when ClientConfig.MaxRetries is set.
+var DefaultRetriableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultPostRetriableStatusCodes are retried instead of the
+// DefaultRetriableStatusCodes for POST requests.  Creates that fail with
+// a gateway error may have succeeded, retrying them creates duplicates.
+var DefaultPostRetriableStatusCodes = []int{
+	http.StatusTooManyRequests,
+}
+
 var (
 	ErrNotFound          = fmt.Errorf("not found")
 	ErrRateLimitExceeded = fmt.Errorf("rate limit exceeded")
@@ -25,6 +45,38 @@ var (
 	ErrNoMorePages       = fmt.Errorf("no more pages")
 )
 
+// StatusError is returned for responses with an unexpected status code
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed with status code %d\n%s", e.StatusCode, e.Body)
+}
+
+// MayHaveSucceeded reports whether a failed write may still have been
+// applied by OneLogin.  This is the case when the response was never
+// received, e.g. the request timed out, or a gateway gave up waiting
+// for OneLogin to respond.
+func MayHaveSucceeded(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, ErrBadGateway) {
+		return true
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusBadGateway ||
+			statusErr.StatusCode == http.StatusGatewayTimeout
+	}
+
+	return false
+}
+
 // Client to execute requests in onelogin
 type Client struct {
 	config         *ClientConfig
@@ -34,6 +86,12 @@ type Client struct {
 
 	maxPageSize map[string]int
 	log         Logger
+
+	// cache is nil unless ReadCache is enabled
+	cache *readCache
+
+	// chain sends the http requests of api calls, see buildChain
+	chain Doer
 }
 
 // ClientConfig sets instance, credentials, timeout
@@ -44,6 +102,49 @@ type ClientConfig struct {
 	Subdomain    string
 	Timeout      time.Duration
 	Logger       Logger
+
+	// ReadCache serves reads of roles and mappings from a single list of
+	// each collection for the lifetime of the client.  Writes invalidate the cache.
+	ReadCache bool
+
+	// MaxRetries is the number of times requests that don't set their own
+	// Retry are retried for DefaultRetriableStatusCodes, or for
+	// DefaultPostRetriableStatusCodes if they are POST requests.
+	// Default is 0.
+	MaxRetries int
+
+	// RetryWait is the wait before the first default retry, doubled for
+	// every subsequent retry.  Default is DefaultRetryWait.
+	RetryWait time.Duration
+
+	// MaxConcurrentRequests limits the number of requests in flight at
+	// once.  Default is 0 which means no limit.
+	MaxConcurrentRequests int
+
+	// HTTPTrace logs every http request and response at trace level.
+	HTTPTrace bool
+
+	// ConsistencyTimeout bounds WaitUntilConsistent.  Default is
+	// DefaultConsistencyTimeout.
+	ConsistencyTimeout time.Duration
+
+	// RedactedKeys are redacted from log fields in addition to
+	// the DefaultRedactedKeys.
+	RedactedKeys []string
+
+	// Hooks instrument api calls, e.g. with OpenTelemetry.  Default is nil
+	// which disables instrumentation.
+	Hooks *Hooks
+
+	// Middlewares wrap every attempt of an api call, e.g. to add headers,
+	// record requests or inject faults.  They run in order after the
+	// built-in retry, timeout, auth and log middlewares, so requests
+	// already carry the access token.
+	Middlewares []Middleware
+
+	// Transport sends the http requests of the client, including token
+	// requests, e.g. a CassetteTransport.  Default is http.DefaultTransport.
+	Transport http.RoundTripper
 }
 
 // authResponse json https://developers.onelogin.com/api-docs/2/oauth20-tokens/generate-tokens-2
@@ -71,6 +172,19 @@ const (
 	PathMappings     = "/api/2/mappings"
 	PathMappingsSort = "/api/2/mappings/sort"
 	PathConnectors   = "/api/2/connectors"
+
+	PathAPIAuthorizations        = "/api/2/api_authorizations"
+	PathSmartHooks               = "/api/2/hooks"
+	PathSmartHookEnvVars         = "/api/2/hooks/envs"
+	PathBrands                   = "/api/2/branding/brands"
+	PathTrustedIDPs              = "/api/2/trusted_idps"
+	PathRiskRules                = "/api/2/risk/rules"
+	PathRiskScores               = "/api/2/risk/scores"
+	PathSelfRegistrationProfiles = "/api/2/self_registration_profiles"
+	PathPrivileges               = "/api/1/privileges"
+	PathGroups                   = "/api/1/groups"
+	PathPolicies                 = "/api/1/policies"
+	PathMFAUsers                 = "/api/2/mfa/users"
 )
 
 type Request struct {
@@ -136,11 +250,35 @@ func NewClient(config *ClientConfig) (*Client, error) {
 		config.Logger = &noopLogger{}
 	}
 
+	// Secrets are redacted before any logger sees the fields
+	log := NewRedactingLogger(config.Logger, config.RedactedKeys...)
+
+	if config.RetryWait == 0 {
+		config.RetryWait = DefaultRetryWait
+	}
+
+	if config.ConsistencyTimeout == 0 {
+		config.ConsistencyTimeout = DefaultConsistencyTimeout
+	}
+
+	transport := config.Transport
+	if config.HTTPTrace {
+		transport = &traceTransport{next: transport, log: log}
+	}
+	if config.MaxConcurrentRequests > 0 {
+		transport = &limitTransport{
+			next: transport,
+			sem:  make(chan struct{}, config.MaxConcurrentRequests),
+		}
+	}
+
 	c := &Client{
 		config: config,
-		log:    config.Logger,
+		log:    log,
+		// Requests are limited by requestContext instead of a client
+		// timeout so that operations can extend the timeout.
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Transport: transport,
 		},
 
 		maxPageSize: map[string]int{
@@ -151,6 +289,12 @@ func NewClient(config *ClientConfig) (*Client, error) {
 		},
 	}
 
+	if config.ReadCache {
+		c.cache = &readCache{}
+	}
+
+	c.chain = c.buildChain()
+
 	// Attempt to authenticate
 	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
 	defer cancel()
@@ -168,7 +312,9 @@ func (c *Client) getToken(ctx context.Context) (string, error) {
 }
 
 func (c *Client) getTokenForce(ctx context.Context) (string, error) {
+	start := time.Now()
 	resp, err := c.authRequest(ctx)
+	c.tokenRefreshed(ctx, start, err)
 	if err != nil {
 		c.authToken = ""
 		c.authExpiration = time.Time{}
@@ -181,7 +327,10 @@ func (c *Client) getTokenForce(ctx context.Context) (string, error) {
 }
 
 func (c *Client) authRequest(ctx context.Context) (*authResponse, error) {
-	authURL := fmt.Sprintf("https://%s.onelogin.com/auth/oauth2/v2/token", c.config.Subdomain)
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	authURL := fmt.Sprintf("https://%s.onelogin.com%s", c.config.Subdomain, pathToken)
 
 	// Convert payload to JSON
 	jsonBody, _ := json.Marshal(map[string]string{
@@ -214,24 +363,48 @@ func (c *Client) authRequest(ctx context.Context) (*authResponse, error) {
 	return &authResponse, err
 }
 
-func (c *Client) ExecRequest(req *Request) (err error) {
-	// statusCode := 0
+func (c *Client) ExecRequest(req *Request) error {
+	if c.cache != nil {
+		if req.Method == MethodGet {
+			ok, err := c.cachedRead(req)
+			if ok {
+				return err
+			}
+		} else {
+			// Invalidate before and after the write so that
+			// reads made during the write are not cached.
+			c.cache.invalidate()
+			defer c.cache.invalidate()
+		}
+	}
+
+	return c.execRequest(req)
+}
+
+func (c *Client) execRequest(req *Request) (err error) {
+	req, call := c.startCall(req)
+	defer func() { call.finish(err) }()
+
+	requestID := newRequestID()
 	c.log.Info(req.Context, "executing request", map[string]interface{}{
-		"method": req.Method,
-		"path":   req.Path,
+		"method":     req.Method,
+		"path":       req.Path,
+		"request_id": requestID,
 	})
 	defer func() {
 		if err != nil {
 			c.log.Error(req.Context, "request failed", map[string]interface{}{
-				"method": req.Method,
-				"path":   req.Path,
-				"error":  err.Error(),
+				"method":     req.Method,
+				"path":       req.Path,
+				"request_id": requestID,
+				"error":      err.Error(),
 			})
 			return
 		}
 		c.log.Info(req.Context, "request succeeded", map[string]interface{}{
-			"method": req.Method,
-			"path":   req.Path,
+			"method":     req.Method,
+			"path":       req.Path,
+			"request_id": requestID,
 		})
 	}()
 
@@ -240,49 +413,59 @@ func (c *Client) ExecRequest(req *Request) (err error) {
 		return err
 	}
 
-	for i := 0; i < (req.Retry + 1); i++ {
-		// Add default timeout
-		ctx, cancel := context.WithTimeout(httpReq.Context(), c.config.Timeout)
-		defer cancel()
-
-		resp, err := c.httpClient.Do(httpReq.WithContext(ctx))
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		if i != req.Retry && c.isRetriable(resp.StatusCode, req.RetriableStatusCodes) {
-			waitDur := req.RetryWait * time.Duration(pow(2, req.RetryBackoffFactor*i))
-			select {
-			case <-httpReq.Context().Done():
-				return httpReq.Context().Err()
-			case <-time.After(waitDur):
-				c.log.Info(req.Context, "retrying request", map[string]interface{}{
-					"method":       req.Method,
-					"path":         req.Path,
-					"resp_code":    resp.StatusCode,
-					"retry_num":    i + 1,
-					"retry_wait_s": waitDur.Seconds(),
-				})
-				continue
-			}
-		} else if resp.StatusCode == 404 {
-			return ErrNotFound
-		} else if resp.StatusCode/100 != 2 {
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("request failed with status code %d\n%s", resp.StatusCode, string(bodyBytes))
-		}
+	resp, err := c.do(httpReq, &callState{
+		req:       c.withDefaultRetries(req),
+		requestID: requestID,
+		call:      call,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-		if req.RespModel != nil {
-			return json.NewDecoder(resp.Body).Decode(req.RespModel)
-		}
+	if resp.StatusCode == 404 {
+		return ErrNotFound
+	} else if resp.StatusCode/100 != 2 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
 
-		return nil
+	if req.RespModel != nil {
+		return json.NewDecoder(resp.Body).Decode(req.RespModel)
 	}
 
 	return nil
 }
 
+// requestContext limits a single request to the client timeout, unless
+// the context already has a deadline, e.g. the timeout of a terraform
+// operation.  The deadline of the context takes precedence so that slow
+// operations can wait longer than the client timeout.
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.config.Timeout)
+}
+
+// withDefaultRetries returns a copy of the request that retries with the
+// client defaults if the request doesn't set its own retries.
+func (c *Client) withDefaultRetries(req *Request) *Request {
+	if req.Retry > 0 || c.config.MaxRetries == 0 {
+		return req
+	}
+
+	r := *req
+	r.Retry = c.config.MaxRetries
+	r.RetriableStatusCodes = DefaultRetriableStatusCodes
+	if req.Method == MethodPost {
+		r.RetriableStatusCodes = DefaultPostRetriableStatusCodes
+	}
+	r.RetryWait = c.config.RetryWait
+	r.RetryBackoffFactor = 1
+	return &r
+}
+
 func pow(x, y int) int {
 	return int(math.Pow(float64(x), float64(y)))
 }
@@ -320,13 +503,6 @@ func (c *Client) requestToHTTP(req *Request) (*http.Request, error) {
 		return nil, err
 	}
 
-	token, err := c.getToken(req.Context)
-	if err != nil {
-		return nil, err
-	}
-
-	httpReq.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
 	if body != nil {
 		httpReq.Header.Add("Content-Type", "application/json")
 	}
@@ -339,20 +515,31 @@ type Page struct {
 	Page  int
 }
 
+// ExecRequestPaged executes a GET request for a single page of a collection
+// and advances page.  ErrNoMorePages is returned after the last page.  It is
+// called through ListAll, which lists collections for the read cache, the
+// export command and the lookups by name of imports and create
+// reconciliation.  Paged requests go through the middleware chain like
+// ExecRequest, but bypass the read cache.
+//
 // Pagination reference: https://developers.onelogin.com/api-docs/2/getting-started/using-query-parameters#pagination
-// Only used in the generator right now.  This method needs to be enhanced to match
-// ExecRequest to use in the provider.
 func (c *Client) ExecRequestPaged(req *Request, page *Page) (err error) {
+	req, call := c.startCall(req)
+	defer func() { call.finish(err) }()
+
 	var errInfo string
+	requestID := newRequestID()
 	c.log.Info(req.Context, "executing paged request", map[string]interface{}{
-		"method": req.Method,
-		"path":   req.Path,
+		"method":     req.Method,
+		"path":       req.Path,
+		"request_id": requestID,
 	})
 	defer func() {
 		if err != nil && err != ErrNoMorePages {
 			c.log.Error(req.Context, "paged request failed", map[string]interface{}{
 				"method":     req.Method,
 				"path":       req.Path,
+				"request_id": requestID,
 				"error":      err.Error(),
 				"error_info": errInfo,
 			})
@@ -361,6 +548,7 @@ func (c *Client) ExecRequestPaged(req *Request, page *Page) (err error) {
 		c.log.Info(req.Context, "request succeeded", map[string]interface{}{
 			"method":     req.Method,
 			"path":       req.Path,
+			"request_id": requestID,
 			"more_pages": err == nil,
 		})
 	}()
@@ -391,11 +579,16 @@ func (c *Client) ExecRequestPaged(req *Request, page *Page) (err error) {
 		return err
 	}
 
-	// Add default timeout
-	ctx, cancel := context.WithTimeout(httpReq.Context(), c.config.Timeout)
-	defer cancel()
-
-	resp, err := c.httpClient.Do(httpReq.WithContext(ctx))
+	// Paged requests are not retried, callers retry 429 and 502 errors
+	resp, err := c.do(httpReq, &callState{
+		req: &Request{
+			Context: req.Context,
+			Method:  req.Method,
+			Path:    req.Path,
+		},
+		requestID: requestID,
+		call:      call,
+	})
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -247,3 +248,331 @@ func TestAuth(t *testing.T) {
 	_, err = c.authRequest(ctx)
 	assert.Error(t, err)
 }
+
+func (s *clientTestSuite) Test_ListAll() {
+	// Three pages of roles with a rate limited response before the second page
+	timesCalled := 0
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles, func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		if timesCalled == 2 {
+			return httpmock.NewStringResponse(http.StatusTooManyRequests, ""), nil
+		}
+
+		s.Equal("650", req.URL.Query().Get("limit"))
+		page := req.URL.Query().Get("page")
+		resp, err := httpmock.NewJsonResponse(200, []Role{
+			{ID: int64(len(page)*100 + timesCalled), Name: "role_page_" + page},
+		})
+		s.Require().NoError(err)
+		resp.Header.Set("Total-Pages", "3")
+		return resp, nil
+	})
+
+	roles, err := ListAll[Role](s.client, &Request{
+		Method:    MethodGet,
+		Path:      PathRoles,
+		Retry:     1,
+		RetryWait: 0,
+	})
+	s.Require().NoError(err)
+	s.Equal(4, timesCalled)
+	s.Require().Len(roles, 3)
+	s.Equal("role_page_1", roles[0].Name)
+	s.Equal("role_page_2", roles[1].Name)
+	s.Equal("role_page_3", roles[2].Name)
+
+	// Rate limit errors are returned once retries are exhausted
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles,
+		httpmock.NewStringResponder(http.StatusTooManyRequests, ""))
+	_, err = ListAll[Role](s.client, &Request{
+		Method: MethodGet,
+		Path:   PathRoles,
+	})
+	s.Equal(ErrRateLimitExceeded, err)
+
+	// Paths without a configured page size are rejected
+	_, err = ListAll[Mapping](s.client, &Request{
+		Method: MethodGet,
+		Path:   PathMappings,
+	})
+	s.Error(err)
+}
+
+func (s *clientTestSuite) Test_ListAllV1() {
+	// Two pages of groups linked by the after cursor
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathGroups, func(req *http.Request) (*http.Response, error) {
+		s.Equal("test", req.URL.Query().Get("name"))
+
+		cursor := req.URL.Query().Get("after_cursor")
+		body := map[string]interface{}{
+			"status":     map[string]interface{}{"error": false, "code": 200},
+			"pagination": map[string]interface{}{"after_cursor": "next"},
+			"data":       []Group{{ID: 1, Name: "group_1"}},
+		}
+		if cursor == "next" {
+			body["pagination"] = map[string]interface{}{"after_cursor": nil}
+			body["data"] = []Group{{ID: 2, Name: "group_2"}}
+		}
+		return httpmock.NewJsonResponse(200, body)
+	})
+
+	groups, err := ListAllV1[Group](s.client, &Request{
+		Method:      MethodGet,
+		Path:        PathGroups,
+		QueryParams: QueryParams{"name": "test"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(groups, 2)
+	s.Equal("group_1", groups[0].Name)
+	s.Equal("group_2", groups[1].Name)
+}
+
+func (s *clientTestSuite) newClient(config *ClientConfig) *Client {
+	config.ClientID = s.clientID
+	config.ClientSecret = s.clientSecret
+	config.Subdomain = s.subdomain
+
+	c, err := NewClient(config)
+	s.Require().NoError(err)
+	return c
+}
+
+func (s *clientTestSuite) Test_DefaultRetries() {
+	c := s.newClient(&ClientConfig{
+		MaxRetries: 2,
+		RetryWait:  time.Millisecond,
+	})
+
+	// Requests without retries use the client defaults and resend the body
+	timesCalled := 0
+	httpmock.RegisterResponder(string(MethodPost), "https://test_subdomain.onelogin.com/test", func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		b, err := io.ReadAll(req.Body)
+		s.Require().NoError(err)
+		s.JSONEq(`{"name":"test"}`, string(b))
+		if timesCalled < 3 {
+			return httpmock.NewStringResponse(http.StatusTooManyRequests, ""), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+	})
+	err := c.ExecRequest(&Request{
+		Method: MethodPost,
+		Path:   "/test",
+		Body:   map[string]string{"name": "test"},
+	})
+	s.Require().NoError(err)
+	s.Equal(3, timesCalled)
+
+	// Creates are not retried for gateway errors, they may have succeeded
+	timesCalled = 0
+	httpmock.RegisterResponder(string(MethodPost), "https://test_subdomain.onelogin.com/test", func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		return httpmock.NewStringResponse(http.StatusBadGateway, ""), nil
+	})
+	err = c.ExecRequest(&Request{
+		Method: MethodPost,
+		Path:   "/test",
+		Body:   map[string]string{"name": "test"},
+	})
+	s.Error(err)
+	s.Equal(1, timesCalled)
+
+	// Other methods are retried for gateway errors
+	timesCalled = 0
+	httpmock.RegisterResponder(string(MethodPut), "https://test_subdomain.onelogin.com/test", func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		return httpmock.NewStringResponse(http.StatusBadGateway, ""), nil
+	})
+	err = c.ExecRequest(&Request{
+		Method: MethodPut,
+		Path:   "/test",
+		Body:   map[string]string{"name": "test"},
+	})
+	s.Error(err)
+	s.Equal(3, timesCalled)
+
+	// Requests with their own retries keep them
+	timesCalled = 0
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com/test", func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		return httpmock.NewStringResponse(http.StatusInternalServerError, ""), nil
+	})
+	err = c.ExecRequest(&Request{
+		Method:               MethodGet,
+		Path:                 "/test",
+		Retry:                1,
+		RetriableStatusCodes: []int{http.StatusInternalServerError},
+	})
+	s.Error(err)
+	s.Equal(2, timesCalled)
+
+	// Status codes that aren't retriable by default fail immediately
+	timesCalled = 0
+	err = c.ExecRequest(&Request{
+		Method: MethodGet,
+		Path:   "/test",
+	})
+	s.Error(err)
+	s.Equal(1, timesCalled)
+}
+
+func (s *clientTestSuite) Test_MaxConcurrentRequests() {
+	c := s.newClient(&ClientConfig{
+		MaxConcurrentRequests: 2,
+	})
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com/test", func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.NoError(c.ExecRequest(&Request{
+				Method: MethodGet,
+				Path:   "/test",
+			}))
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(2, maxInFlight)
+}
+
+type recordingLogger struct {
+	noopLogger
+	mu     sync.Mutex
+	traces []map[string]interface{}
+}
+
+func (l *recordingLogger) Trace(_ context.Context, msg string, fields ...map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	merged := mergFields(fields...)
+	merged["msg"] = msg
+	l.traces = append(l.traces, merged)
+}
+
+func (s *clientTestSuite) Test_HTTPTrace() {
+	logger := &recordingLogger{}
+	c := s.newClient(&ClientConfig{
+		HTTPTrace: true,
+		Logger:    logger,
+	})
+
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com/test",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+	s.Require().NoError(c.ExecRequest(&Request{
+		Method: MethodGet,
+		Path:   "/test",
+	}))
+
+	// The auth request is traced as well, the http exchange
+	// record of the request is logged last
+	s.Require().Len(logger.traces, 5)
+	last := logger.traces[3]
+	s.Equal("http response", last["msg"])
+	s.Equal(http.StatusOK, last["status_code"])
+	s.Equal("https://test_subdomain.onelogin.com/test", last["url"])
+}
+
+func (s *clientTestSuite) Test_RequestContext() {
+	s.client.config.Timeout = time.Minute
+
+	// Without a deadline the client timeout applies
+	ctx, cancel := s.client.requestContext(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	s.Require().True(ok)
+	s.WithinDuration(time.Now().Add(time.Minute), deadline, time.Second)
+
+	// The deadline of the operation takes precedence
+	opCtx, opCancel := context.WithTimeout(context.Background(), time.Hour)
+	defer opCancel()
+	ctx, cancel = s.client.requestContext(opCtx)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	s.Require().True(ok)
+	s.WithinDuration(time.Now().Add(time.Hour), deadline, time.Second)
+}
+
+func (s *clientTestSuite) Test_MayHaveSucceeded() {
+	httpmock.RegisterResponder(string(MethodPost), "https://test_subdomain.onelogin.com/timeout",
+		httpmock.NewErrorResponder(context.DeadlineExceeded))
+	httpmock.RegisterResponder(string(MethodPost), "https://test_subdomain.onelogin.com/gateway",
+		httpmock.NewStringResponder(http.StatusGatewayTimeout, ""))
+	httpmock.RegisterResponder(string(MethodPost), "https://test_subdomain.onelogin.com/invalid",
+		httpmock.NewStringResponder(http.StatusUnprocessableEntity, "invalid"))
+
+	for path, expected := range map[string]bool{
+		"/timeout": true,
+		"/gateway": true,
+		"/invalid": false,
+	} {
+		err := s.client.ExecRequest(&Request{
+			Method: MethodPost,
+			Path:   path,
+		})
+		s.Require().Error(err, path)
+		s.Equal(expected, MayHaveSucceeded(err), path)
+	}
+
+	s.False(MayHaveSucceeded(ErrNotFound))
+}
+
+func (s *clientTestSuite) Test_WireTrace() {
+	logger := &recordingLogger{}
+	c := s.newClient(&ClientConfig{
+		Logger: logger,
+	})
+
+	timesCalled := 0
+	httpmock.RegisterResponder(string(MethodPost), "https://test_subdomain.onelogin.com/test", func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		if timesCalled == 1 {
+			return httpmock.NewStringResponse(http.StatusTooManyRequests, ""), nil
+		}
+		resp := httpmock.NewStringResponse(http.StatusOK, `{"id":1,"access_token":"token"}`)
+		resp.Header.Set("X-RateLimit-Remaining", "4999")
+		return resp, nil
+	})
+	s.Require().NoError(c.ExecRequest(&Request{
+		Method:               MethodPost,
+		Path:                 "/test",
+		QueryParams:          QueryParams{"name": "app"},
+		Body:                 map[string]string{"client_secret": "secret"},
+		Retry:                1,
+		RetriableStatusCodes: []int{http.StatusTooManyRequests},
+	}))
+
+	s.Require().Len(logger.traces, 2)
+	first, last := logger.traces[0], logger.traces[1]
+	s.Equal("http exchange", last["msg"])
+	s.NotEmpty(last["request_id"])
+	s.Equal(first["request_id"], last["request_id"])
+	s.Equal(0, first["attempt"])
+	s.Equal(http.StatusTooManyRequests, first["status_code"])
+	s.Equal(1, last["attempt"])
+	s.Equal(http.StatusOK, last["status_code"])
+	s.Equal("name=app", last["query"])
+	s.Equal("4999", last["rate_limit_remaining"])
+	s.JSONEq(`{"client_secret":"[REDACTED]"}`, last["request_body"].(string))
+	s.JSONEq(`{"id":1,"access_token":"[REDACTED]"}`, last["response_body"].(string))
+	s.Contains(last, "duration_ms")
+}
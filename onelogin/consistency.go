@@ -0,0 +1,69 @@
+package onelogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotConsistent is returned by WaitUntilConsistent if the object
+// does not reflect a write before the timeout.
+var ErrNotConsistent = fmt.Errorf("not consistent")
+
+// consistencyPollInterval is the wait between the reads of WaitUntilConsistent
+var consistencyPollInterval = time.Second
+
+// WaitUntilConsistent reads the object of the GET request until consistent
+// returns true for it.
+//
+// OneLogin is eventually consistent, a read right after a write may return
+// the object as it was before the write, or not find a new object at all.
+// Reads bypass the read cache.  The object read last is returned with
+// ErrNotConsistent if it doesn't become consistent within the
+// ConsistencyTimeout of the client.
+func WaitUntilConsistent[T any](c *Client, req *Request, consistent func(*T) bool) (*T, error) {
+	if req.Context == nil {
+		req.Context = context.Background()
+	}
+
+	timeout := c.config.ConsistencyTimeout
+	ctx, cancel := context.WithTimeout(req.Context, timeout)
+	defer cancel()
+
+	var last *T
+	for attempt := 1; ; attempt++ {
+		var obj T
+		pollReq := *req
+		pollReq.Context = ctx
+		pollReq.RespModel = &obj
+
+		err := c.execRequest(&pollReq)
+		switch {
+		case err == nil && consistent(&obj):
+			return &obj, nil
+		case err == nil:
+			last = &obj
+		case errors.Is(err, ErrNotFound):
+			// New objects may not be found yet
+		case ctx.Err() != nil && req.Context.Err() == nil:
+			return last, fmt.Errorf("%w after %s", ErrNotConsistent, timeout)
+		default:
+			return nil, err
+		}
+
+		c.log.Debug(req.Context, "waiting for consistent read", map[string]interface{}{
+			"path":    req.Path,
+			"attempt": attempt,
+		})
+
+		select {
+		case <-ctx.Done():
+			if req.Context.Err() != nil {
+				return nil, req.Context.Err()
+			}
+			return last, fmt.Errorf("%w after %s", ErrNotConsistent, timeout)
+		case <-time.After(consistencyPollInterval):
+		}
+	}
+}
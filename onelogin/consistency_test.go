@@ -0,0 +1,73 @@
+package onelogin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *clientTestSuite) Test_WaitUntilConsistent() {
+	defer func(interval time.Duration) { consistencyPollInterval = interval }(consistencyPollInterval)
+	consistencyPollInterval = time.Millisecond
+
+	c := s.newClient(&ClientConfig{
+		ConsistencyTimeout: 100 * time.Millisecond,
+	})
+
+	// New objects are not found at first, then returned without the write
+	timesCalled := 0
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles+"/1", func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		switch timesCalled {
+		case 1:
+			return httpmock.NewStringResponse(http.StatusNotFound, ""), nil
+		case 2:
+			return httpmock.NewJsonResponse(http.StatusOK, Role{ID: 1, Name: "role"})
+		default:
+			return httpmock.NewJsonResponse(http.StatusOK, Role{ID: 1, Name: "role", Users: []int64{10}})
+		}
+	})
+
+	hasUser := func(r *Role) bool { return len(r.Users) == 1 }
+	role, err := WaitUntilConsistent(c, &Request{
+		Method: MethodGet,
+		Path:   PathRoles + "/1",
+	}, hasUser)
+	s.Require().NoError(err)
+	s.Equal([]int64{10}, role.Users)
+	s.Equal(3, timesCalled)
+
+	// The last read is returned if the object never becomes consistent
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles+"/2",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, Role{ID: 2, Name: "stale"}))
+	role, err = WaitUntilConsistent(c, &Request{
+		Method: MethodGet,
+		Path:   PathRoles + "/2",
+	}, hasUser)
+	s.True(errors.Is(err, ErrNotConsistent))
+	s.Require().NotNil(role)
+	s.Equal("stale", role.Name)
+
+	// Other errors are returned right away
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles+"/3",
+		httpmock.NewStringResponder(http.StatusUnauthorized, ""))
+	role, err = WaitUntilConsistent(c, &Request{
+		Method: MethodGet,
+		Path:   PathRoles + "/3",
+	}, hasUser)
+	s.Nil(role)
+	s.ErrorContains(err, "status code 401")
+
+	// The deadline of the caller is not reported as inconsistency
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitUntilConsistent(c, &Request{
+		Context: ctx,
+		Method:  MethodGet,
+		Path:    PathRoles + "/2",
+	}, hasUser)
+	s.False(errors.Is(err, ErrNotConsistent))
+}
@@ -0,0 +1,10 @@
+package onelogin
+
+// Groups are read only in the api
+// https://developers.onelogin.com/api-docs/1/groups/get-groups
+type Group struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Reference *string `json:"reference"`
+	PolicyID  *int64  `json:"policy_id,omitempty"`
+}
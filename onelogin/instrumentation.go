@@ -0,0 +1,185 @@
+package onelogin
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hooks instrument the api calls of the client, e.g. to create a span per
+// call and record metrics with OpenTelemetry.  All hooks are optional and
+// must be safe for concurrent use.
+type Hooks struct {
+	// OnRequestStart is called before an api call.  The returned context
+	// is used for the call, e.g. to carry a span, and the returned func
+	// is called once with the result after the last attempt of the call.
+	OnRequestStart func(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult))
+
+	// OnRetry is called before an api call is retried
+	OnRetry func(ctx context.Context, info RequestInfo, attempt int, statusCode int)
+
+	// OnRateLimited is called for every 429 response
+	OnRateLimited func(ctx context.Context, info RequestInfo)
+
+	// OnTokenRefresh is called after every access token request
+	OnTokenRefresh func(ctx context.Context, duration time.Duration, err error)
+}
+
+// RequestInfo describes an api call
+type RequestInfo struct {
+	Method string
+	Path   string
+
+	// PathTemplate is the path with ids replaced by {id}, e.g.
+	// /api/2/roles/{id}.  Use it instead of Path to tag metrics.
+	PathTemplate string
+}
+
+// RequestResult is the outcome of an api call
+type RequestResult struct {
+	// StatusCode of the last response, 0 if no response was received
+	StatusCode int
+	Duration   time.Duration
+	Attempts   int
+	Err        error
+}
+
+// stringIDCollections are the collections with ids that are not numeric,
+// e.g. uuids.  Collections nested in another one come first.
+var stringIDCollections = []string{
+	PathSmartHookEnvVars,
+	PathSmartHooks,
+	PathRiskRules,
+	PathPrivileges,
+}
+
+// PathTemplate replaces the ids in the path with {id}.  Ids are the
+// numeric segments and the segment after a collection with string ids.
+func PathTemplate(path string) string {
+	for _, collection := range stringIDCollections {
+		rest, ok := strings.CutPrefix(path, collection+"/")
+		if !ok {
+			continue
+		}
+
+		id, sub, hasSub := strings.Cut(rest, "/")
+		if slices.Contains(stringIDCollections, collection+"/"+id) {
+			continue
+		}
+		if id != "" {
+			path = collection + "/{id}"
+			if hasSub {
+				path += "/" + sub
+			}
+		}
+		break
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		// The segment after api is the version of the api
+		if i > 0 && segments[i-1] == "api" {
+			continue
+		}
+		if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// instrumentedCall tracks the result of a single api call for the hooks
+type instrumentedCall struct {
+	hooks *Hooks
+	ctx   context.Context
+	info  RequestInfo
+	start time.Time
+	end   func(RequestResult)
+
+	statusCode int
+	attempts   int
+}
+
+// startCall calls OnRequestStart and returns a copy of the request with the
+// returned context.  Requests are returned as is if there are no hooks.
+func (c *Client) startCall(req *Request) (*Request, *instrumentedCall) {
+	if c.config.Hooks == nil {
+		return req, nil
+	}
+
+	if req.Context == nil {
+		req.Context = context.Background()
+	}
+
+	call := &instrumentedCall{
+		hooks: c.config.Hooks,
+		ctx:   req.Context,
+		info: RequestInfo{
+			Method:       string(req.Method),
+			Path:         req.Path,
+			PathTemplate: PathTemplate(req.Path),
+		},
+		start: time.Now(),
+	}
+
+	if call.hooks.OnRequestStart != nil {
+		ctx, end := call.hooks.OnRequestStart(req.Context, call.info)
+		if ctx != nil {
+			r := *req
+			r.Context = ctx
+			req = &r
+			call.ctx = ctx
+		}
+		call.end = end
+	}
+
+	return req, call
+}
+
+// attempted records the status code of an attempt, 0 if the
+// attempt failed without a response
+func (call *instrumentedCall) attempted(statusCode int) {
+	if call == nil {
+		return
+	}
+
+	call.attempts++
+	call.statusCode = statusCode
+	if statusCode == 429 && call.hooks.OnRateLimited != nil {
+		call.hooks.OnRateLimited(call.ctx, call.info)
+	}
+}
+
+func (call *instrumentedCall) retry(attempt int, statusCode int) {
+	if call == nil || call.hooks.OnRetry == nil {
+		return
+	}
+	call.hooks.OnRetry(call.ctx, call.info, attempt, statusCode)
+}
+
+// finish reports the result of the call, errors that only signal the
+// end of paging are not reported.
+func (call *instrumentedCall) finish(err error) {
+	if call == nil || call.end == nil {
+		return
+	}
+
+	if err == ErrNoMorePages {
+		err = nil
+	}
+	call.end(RequestResult{
+		StatusCode: call.statusCode,
+		Duration:   time.Since(call.start),
+		Attempts:   call.attempts,
+		Err:        err,
+	})
+}
+
+func (c *Client) tokenRefreshed(ctx context.Context, start time.Time, err error) {
+	if c.config.Hooks == nil || c.config.Hooks.OnTokenRefresh == nil {
+		return
+	}
+	c.config.Hooks.OnTokenRefresh(ctx, time.Since(start), err)
+}
@@ -0,0 +1,116 @@
+package onelogin
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+// memoryExporter records the calls of the hooks in memory
+type memoryExporter struct {
+	mu            sync.Mutex
+	spans         []string
+	results       []RequestResult
+	retries       map[string]int
+	rateLimited   map[string]int
+	tokenRefreshs int
+}
+
+func (m *memoryExporter) hooks() *Hooks {
+	m.retries = map[string]int{}
+	m.rateLimited = map[string]int{}
+	return &Hooks{
+		OnRequestStart: func(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult)) {
+			span := info.Method + " " + info.PathTemplate
+			return context.WithValue(ctx, spanKey{}, span), func(result RequestResult) {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				m.spans = append(m.spans, span)
+				m.results = append(m.results, result)
+			}
+		},
+		OnRetry: func(ctx context.Context, info RequestInfo, attempt int, statusCode int) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.retries[ctx.Value(spanKey{}).(string)]++
+		},
+		OnRateLimited: func(ctx context.Context, info RequestInfo) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.rateLimited[info.PathTemplate]++
+		},
+		OnTokenRefresh: func(ctx context.Context, duration time.Duration, err error) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.tokenRefreshs++
+		},
+	}
+}
+
+func (s *clientTestSuite) Test_Hooks() {
+	exporter := &memoryExporter{}
+	c := s.newClient(&ClientConfig{
+		Hooks: exporter.hooks(),
+	})
+	s.Equal(1, exporter.tokenRefreshs)
+
+	timesCalled := 0
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com"+PathRoles+"/12", func(req *http.Request) (*http.Response, error) {
+		timesCalled++
+		if timesCalled == 1 {
+			return httpmock.NewStringResponse(http.StatusTooManyRequests, ""), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{"id":12}`), nil
+	})
+	httpmock.RegisterResponder(string(MethodDelete), "https://test_subdomain.onelogin.com"+PathRoles+"/13",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	var role Role
+	s.Require().NoError(c.ExecRequest(&Request{
+		Method:               MethodGet,
+		Path:                 PathRoles + "/12",
+		RespModel:            &role,
+		Retry:                1,
+		RetriableStatusCodes: []int{http.StatusTooManyRequests},
+	}))
+	s.Equal(ErrNotFound, c.ExecRequest(&Request{
+		Method: MethodDelete,
+		Path:   PathRoles + "/13",
+	}))
+
+	s.Equal([]string{"GET /api/2/roles/{id}", "DELETE /api/2/roles/{id}"}, exporter.spans)
+	s.Equal(2, exporter.results[0].Attempts)
+	s.Equal(http.StatusOK, exporter.results[0].StatusCode)
+	s.NoError(exporter.results[0].Err)
+	s.Positive(exporter.results[0].Duration)
+	s.Equal(http.StatusNotFound, exporter.results[1].StatusCode)
+	s.Equal(ErrNotFound, exporter.results[1].Err)
+	s.Equal(map[string]int{"GET /api/2/roles/{id}": 1}, exporter.retries)
+	s.Equal(map[string]int{"/api/2/roles/{id}": 1}, exporter.rateLimited)
+}
+
+func TestPathTemplate(t *testing.T) {
+	assert.Equal(t, "/api/2/roles", PathTemplate("/api/2/roles"))
+	assert.Equal(t, "/api/2/roles/{id}", PathTemplate("/api/2/roles/123"))
+	assert.Equal(t, "/api/2/roles/{id}/users", PathTemplate("/api/2/roles/123/users"))
+	assert.Equal(t, "/api/2/mfa/users/{id}/registrations/{id}", PathTemplate("/api/2/mfa/users/1/registrations/2"))
+	assert.Equal(t, "/api/2/apps/{id}/{id}", PathTemplate("/api/2/apps/1/2"))
+
+	// Collections with string ids
+	uuid := "0f7ac44b-8d1e-4c5a-9a3e-5f0d3f8d6b21"
+	assert.Equal(t, "/api/2/hooks", PathTemplate("/api/2/hooks"))
+	assert.Equal(t, "/api/2/hooks/{id}", PathTemplate("/api/2/hooks/"+uuid))
+	assert.Equal(t, "/api/2/hooks/{id}/logs", PathTemplate("/api/2/hooks/"+uuid+"/logs"))
+	assert.Equal(t, "/api/2/hooks/envs", PathTemplate("/api/2/hooks/envs"))
+	assert.Equal(t, "/api/2/hooks/envs/{id}", PathTemplate("/api/2/hooks/envs/"+uuid))
+	assert.Equal(t, "/api/2/risk/rules/{id}", PathTemplate("/api/2/risk/rules/"+uuid))
+	assert.Equal(t, "/api/1/privileges/{id}/roles", PathTemplate("/api/1/privileges/"+uuid+"/roles"))
+	assert.Equal(t, "/api/2/mfa/users/{id}/devices/{id}", PathTemplate("/api/2/mfa/users/1/devices/2"))
+}
@@ -3,9 +3,12 @@ package onelogin
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -126,6 +129,78 @@ func (d *devLogger) log(level LogLevel, msg string, fields ...map[string]interfa
 	}
 }
 
+// jsonLogger writes one json object per line.  The keys of the time, level
+// and message match the json logs of terraform, TF_LOG=JSON.
+type jsonLogger struct {
+	level LogLevel
+	out   io.Writer
+	mu    sync.Mutex
+}
+
+func NewJSONLogger(level LogLevel, out io.Writer) Logger {
+	return &jsonLogger{
+		level: level,
+		out:   out,
+		mu:    sync.Mutex{},
+	}
+}
+
+func (j *jsonLogger) Trace(_ context.Context, msg string, fields ...map[string]interface{}) {
+	j.log(Trace, msg, fields...)
+}
+
+func (j *jsonLogger) Debug(_ context.Context, msg string, fields ...map[string]interface{}) {
+	j.log(Debug, msg, fields...)
+}
+
+func (j *jsonLogger) Info(_ context.Context, msg string, fields ...map[string]interface{}) {
+	j.log(Info, msg, fields...)
+}
+
+func (j *jsonLogger) Warn(_ context.Context, msg string, fields ...map[string]interface{}) {
+	j.log(Warn, msg, fields...)
+}
+
+func (j *jsonLogger) Error(_ context.Context, msg string, fields ...map[string]interface{}) {
+	// errors are always printed
+	j.log(Error, msg, fields...)
+}
+
+func (j *jsonLogger) log(level LogLevel, msg string, fields ...map[string]interface{}) {
+	if level < j.level {
+		return
+	}
+
+	entry := mergFields(fields...)
+	for k, v := range entry {
+		// errors have no exported fields and marshal to {}
+		if err, ok := v.(error); ok {
+			entry[k] = err.Error()
+		}
+	}
+	entry["@timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["@level"] = strings.ToLower(logLevelToString(level))
+	entry["@message"] = msg
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		// Fall back to the string representation of values
+		// that can't be marshalled, e.g. channels or funcs
+		for k, v := range entry {
+			entry[k] = fmt.Sprint(v)
+		}
+		b, _ = json.Marshal(entry)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, err = j.out.Write(append(b, '\n'))
+	if err != nil {
+		os.Stderr.WriteString(fmt.Sprintf("error writing log: %v\n", err))
+	}
+}
+
 func logLevelToString(level LogLevel) string {
 	switch level {
 	case Trace:
@@ -0,0 +1,78 @@
+package onelogin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewJSONLogger(Info, buf)
+
+	logger.Debug(context.Background(), "hidden")
+	logger.Info(context.Background(), "executing request", map[string]interface{}{
+		"method": "GET",
+		"status": 200,
+	}, map[string]interface{}{
+		"error": errors.New("failed"),
+	})
+	logger.Error(context.Background(), "unmarshallable", map[string]interface{}{
+		"ch": make(chan int),
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	entry := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "info", entry["@level"])
+	assert.Equal(t, "executing request", entry["@message"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, float64(200), entry["status"])
+	assert.Equal(t, "failed", entry["error"])
+	assert.NotEmpty(t, entry["@timestamp"])
+
+	entry = map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "error", entry["@level"])
+	assert.IsType(t, "", entry["ch"])
+}
+
+func TestRedactingLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	redacting := NewRedactingLogger(logger, "api_key")
+
+	fields := map[string]interface{}{
+		"client_secret": "secret",
+		"API_KEY":       "key",
+		"path":          "/api/2/apps",
+		"headers": http.Header{
+			"Authorization": []string{"bearer token"},
+			"Content-Type":  []string{"application/json"},
+		},
+		"body":   `{"name":"app","sso":{"client_secret":"secret"},"certificates":[{"certificate_value":"cert"}]}`,
+		"nested": map[string]interface{}{"access_token": "token", "id": 1},
+	}
+	redacting.Trace(context.Background(), "request", fields)
+
+	require.Len(t, logger.traces, 1)
+	logged := logger.traces[0]
+	assert.Equal(t, RedactedValue, logged["client_secret"])
+	assert.Equal(t, RedactedValue, logged["API_KEY"])
+	assert.Equal(t, "/api/2/apps", logged["path"])
+	assert.Equal(t, []string{RedactedValue}, logged["headers"].(http.Header)["Authorization"])
+	assert.Equal(t, []string{"application/json"}, logged["headers"].(http.Header)["Content-Type"])
+	assert.JSONEq(t, `{"name":"app","sso":{"client_secret":"[REDACTED]"},"certificates":[{"certificate_value":"[REDACTED]"}]}`, logged["body"].(string))
+	assert.Equal(t, map[string]interface{}{"access_token": RedactedValue, "id": 1}, logged["nested"])
+
+	// The fields of the caller are not modified
+	assert.Equal(t, "secret", fields["client_secret"])
+}
@@ -0,0 +1,34 @@
+package onelogin
+
+// AuthFactor is a factor available for a user to enroll in
+// https://developers.onelogin.com/api-docs/2/multi-factor-authentication/available-factors
+type AuthFactor struct {
+	FactorID       int64  `json:"factor_id"`
+	Name           string `json:"name"`
+	AuthFactorName string `json:"auth_factor_name"`
+}
+
+// MFADevice is a factor a user is enrolled in
+// https://developers.onelogin.com/api-docs/2/multi-factor-authentication/enrolled-factors
+type MFADevice struct {
+	DeviceID        int64  `json:"device_id"`
+	UserDisplayName string `json:"user_display_name"`
+	TypeDisplayName string `json:"type_display_name"`
+	AuthFactorName  string `json:"auth_factor_name"`
+	Default         bool   `json:"default"`
+}
+
+// MFARegistration enrolls a user in a factor.  Setting Verified skips the
+// verification step for SMS, Voice and Email factors.
+// https://developers.onelogin.com/api-docs/2/multi-factor-authentication/enroll-factor
+type MFARegistration struct {
+	FactorID    int64  `json:"factor_id"`
+	DisplayName string `json:"display_name,omitempty"`
+	Number      string `json:"number,omitempty"`
+	Verified    bool   `json:"verified,omitempty"`
+}
+
+type MFARegistrationStatus struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
@@ -0,0 +1,195 @@
+package onelogin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Doer sends a single http request.  *http.Client is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to a Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the next Doer of the chain.  A middleware can change
+// the request before calling next, change the response after, or answer
+// the request without calling next at all.  Middlewares must not modify
+// the request they are given, clone it instead.
+type Middleware func(next Doer) Doer
+
+// callState is the state of an api call shared by the middlewares
+// of the chain.  It is carried in the context of the http request.
+type callState struct {
+	req       *Request
+	requestID string
+	call      *instrumentedCall
+
+	// attempt is the current attempt, starting at 0
+	attempt int
+}
+
+type callStateKey struct{}
+
+func callStateFromContext(ctx context.Context) *callState {
+	if state, ok := ctx.Value(callStateKey{}).(*callState); ok {
+		return state
+	}
+	return &callState{req: &Request{}}
+}
+
+// buildChain wraps the http client in the built-in middlewares followed by
+// the middlewares of the config.  The first middleware is the outermost:
+//
+//	retry -> timeout -> auth -> log -> ClientConfig.Middlewares -> http client
+func (c *Client) buildChain() Doer {
+	middlewares := []Middleware{
+		c.retryMiddleware,
+		c.timeoutMiddleware,
+		c.authMiddleware,
+		c.logMiddleware,
+	}
+	middlewares = append(middlewares, c.config.Middlewares...)
+
+	var doer Doer = c.httpClient
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// do sends the http request of an api call through the middleware chain
+func (c *Client) do(httpReq *http.Request, state *callState) (*http.Response, error) {
+	chain := c.chain
+	if chain == nil {
+		chain = c.buildChain()
+	}
+
+	ctx := context.WithValue(httpReq.Context(), callStateKey{}, state)
+	return chain.Do(httpReq.WithContext(ctx))
+}
+
+// retryMiddleware resends requests that fail with one of the
+// RetriableStatusCodes of the request.
+func (c *Client) retryMiddleware(next Doer) Doer {
+	return DoerFunc(func(httpReq *http.Request) (*http.Response, error) {
+		state := callStateFromContext(httpReq.Context())
+		req := state.req
+
+		for i := 0; ; i++ {
+			// The body is consumed by every attempt
+			if i > 0 && httpReq.GetBody != nil {
+				body, err := httpReq.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				httpReq = httpReq.Clone(httpReq.Context())
+				httpReq.Body = body
+			}
+
+			state.attempt = i
+			resp, err := next.Do(httpReq)
+			if err != nil {
+				return nil, err
+			}
+
+			if i >= req.Retry || !c.isRetriable(resp.StatusCode, req.RetriableStatusCodes) {
+				return resp, nil
+			}
+			resp.Body.Close()
+
+			waitDur := req.RetryWait * time.Duration(pow(2, req.RetryBackoffFactor*i))
+			select {
+			case <-httpReq.Context().Done():
+				return nil, httpReq.Context().Err()
+			case <-time.After(waitDur):
+				state.call.retry(i+1, resp.StatusCode)
+				c.log.Info(req.Context, "retrying request", map[string]interface{}{
+					"method":       req.Method,
+					"path":         req.Path,
+					"request_id":   state.requestID,
+					"resp_code":    resp.StatusCode,
+					"retry_num":    i + 1,
+					"retry_wait_s": waitDur.Seconds(),
+				})
+			}
+		}
+	})
+}
+
+// timeoutMiddleware limits every attempt with requestContext.  The
+// context is canceled when the response body is closed.
+func (c *Client) timeoutMiddleware(next Doer) Doer {
+	return DoerFunc(func(httpReq *http.Request) (*http.Response, error) {
+		ctx, cancel := c.requestContext(httpReq.Context())
+
+		resp, err := next.Do(httpReq.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		return resp, nil
+	})
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// authMiddleware sets the access token, which is refreshed when it expires
+func (c *Client) authMiddleware(next Doer) Doer {
+	return DoerFunc(func(httpReq *http.Request) (*http.Response, error) {
+		token, err := c.getToken(httpReq.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		httpReq = httpReq.Clone(httpReq.Context())
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		return next.Do(httpReq)
+	})
+}
+
+// logMiddleware traces every attempt and reports it to the hooks.  The
+// response body is read up front to include it in the trace.
+func (c *Client) logMiddleware(next Doer) Doer {
+	return DoerFunc(func(httpReq *http.Request) (*http.Response, error) {
+		state := callStateFromContext(httpReq.Context())
+
+		start := time.Now()
+		resp, err := next.Do(httpReq)
+		if err != nil {
+			c.traceWire(state, httpReq, nil, nil, start, err)
+			state.call.attempted(0)
+			return nil, err
+		}
+		state.call.attempted(resp.StatusCode)
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		c.traceWire(state, httpReq, resp, respBody, start, err)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		return resp, nil
+	})
+}
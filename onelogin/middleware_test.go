@@ -0,0 +1,76 @@
+package onelogin
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *clientTestSuite) Test_Middlewares() {
+	order := []string{}
+	recorded := []string{}
+
+	header := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			order = append(order, "header")
+			s.Equal("Bearer test_access_token", req.Header.Get("Authorization"))
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Team", "platform")
+			return next.Do(req)
+		})
+	}
+
+	// Fails the first attempt, the built-in retry resends the request
+	faults := 0
+	fault := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			order = append(order, "fault")
+			if faults == 0 {
+				faults++
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     http.Header{},
+					Request:    req,
+				}, nil
+			}
+			return next.Do(req)
+		})
+	}
+
+	record := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			if err == nil {
+				recorded = append(recorded, req.Method+" "+req.URL.Path+" "+resp.Status)
+			}
+			return resp, err
+		})
+	}
+
+	c := s.newClient(&ClientConfig{
+		Middlewares: []Middleware{header, fault, record},
+	})
+
+	httpmock.RegisterResponder(string(MethodGet), "https://test_subdomain.onelogin.com/test", func(req *http.Request) (*http.Response, error) {
+		s.Equal("platform", req.Header.Get("X-Team"))
+		return httpmock.NewStringResponse(http.StatusOK, `{"id":1}`), nil
+	})
+
+	var resp struct {
+		ID int64 `json:"id"`
+	}
+	s.Require().NoError(c.ExecRequest(&Request{
+		Method:               MethodGet,
+		Path:                 "/test",
+		RespModel:            &resp,
+		Retry:                1,
+		RetriableStatusCodes: []int{http.StatusServiceUnavailable},
+	}))
+
+	s.Equal(int64(1), resp.ID)
+	s.Equal([]string{"header", "fault", "header", "fault"}, order)
+	s.Equal([]string{"GET /test 200"}, recorded)
+}
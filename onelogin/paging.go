@@ -0,0 +1,94 @@
+package onelogin
+
+import (
+	"math"
+	"time"
+)
+
+// ListAll executes a paged GET request for every page and returns the combined results.
+//
+// Rate limit and bad gateway errors are retried using the Retry and RetryWait
+// settings on the request.  All other errors are returned immediately.
+func ListAll[T any](c *Client, req *Request) ([]T, error) {
+	all := []T{}
+	page := &Page{
+		// Limit is reduced to the max page size for the path
+		Limit: math.MaxInt32,
+		Page:  1,
+	}
+
+	retries := 0
+	for {
+		var resp []T
+		req.RespModel = &resp
+
+		err := c.ExecRequestPaged(req, page)
+		if (err == ErrRateLimitExceeded || err == ErrBadGateway) && retries < req.Retry {
+			retries++
+			select {
+			case <-req.Context.Done():
+				return nil, req.Context.Err()
+			case <-time.After(req.RetryWait):
+			}
+			continue
+		}
+		if err != nil && err != ErrNoMorePages {
+			return nil, err
+		}
+
+		all = append(all, resp...)
+		if err == ErrNoMorePages || len(resp) == 0 {
+			return all, nil
+		}
+
+		retries = 0
+		page.Page++
+	}
+}
+
+// v1Response is the envelope wrapping list responses of the version 1 api
+// https://developers.onelogin.com/api-docs/1/getting-started/working-with-api-responses
+type v1Response[T any] struct {
+	Data       []T          `json:"data"`
+	Pagination v1Pagination `json:"pagination"`
+}
+
+type v1Pagination struct {
+	AfterCursor *string `json:"after_cursor"`
+}
+
+// ListAllV1 executes a cursor paged GET request against a version 1 api path
+// for every page and returns the combined results.
+//
+// Errors are retried using the Retry, RetriableStatusCodes and RetryWait
+// settings on the request.
+func ListAllV1[T any](c *Client, req *Request) ([]T, error) {
+	all := []T{}
+
+	queryParams := QueryParams{}
+	if req.QueryParams != nil {
+		if q, ok := req.QueryParams.(QueryParams); ok {
+			for k, v := range q {
+				queryParams[k] = v
+			}
+		}
+	}
+	req.QueryParams = queryParams
+
+	for {
+		var resp v1Response[T]
+		req.RespModel = &resp
+
+		err := c.ExecRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, resp.Data...)
+		if resp.Pagination.AfterCursor == nil || *resp.Pagination.AfterCursor == "" || len(resp.Data) == 0 {
+			return all, nil
+		}
+
+		queryParams["after_cursor"] = *resp.Pagination.AfterCursor
+	}
+}
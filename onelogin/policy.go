@@ -0,0 +1,10 @@
+package onelogin
+
+// Policies are read only in the api
+// https://developers.onelogin.com/api-docs/1/policies/get-policies
+type Policy struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,31 @@
+package onelogin
+
+// https://developers.onelogin.com/api-docs/1/privileges/privileges
+type Privilege struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Privilege   PrivilegePolicy `json:"privilege"`
+}
+
+// PrivilegeVersion is the only supported version of the privilege policy
+const PrivilegeVersion = "2018-05-18"
+
+type PrivilegePolicy struct {
+	Version   string               `json:"Version"`
+	Statement []PrivilegeStatement `json:"Statement"`
+}
+
+type PrivilegeStatement struct {
+	Effect string   `json:"Effect"`
+	Action []string `json:"Action"`
+	Scope  []string `json:"Scope"`
+}
+
+type PrivilegeRoles struct {
+	Roles []int64 `json:"roles"`
+}
+
+type PrivilegeUsers struct {
+	Users []int64 `json:"users"`
+}
@@ -0,0 +1,146 @@
+package onelogin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted log fields
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedKeys are always redacted from the fields of the client logs.
+// Keys are matched case insensitively at any depth of the fields.
+var DefaultRedactedKeys = []string{
+	"authorization",
+	"client_secret",
+	"access_token",
+	"refresh_token",
+	"password",
+	"certificate_value",
+	"private_key",
+	"secret",
+}
+
+// redactingLogger masks the values of the redacted keys before the fields
+// are passed to the next logger.  Nested maps and slices, http headers and
+// strings containing json objects, e.g. request bodies, are redacted as well.
+type redactingLogger struct {
+	*redactor
+	next Logger
+}
+
+// NewRedactingLogger wraps next so that it never sees the values of the
+// keys.  The DefaultRedactedKeys are always redacted.
+func NewRedactingLogger(next Logger, keys ...string) Logger {
+	return &redactingLogger{
+		redactor: newRedactor(keys...),
+		next:     next,
+	}
+}
+
+func (r *redactingLogger) Trace(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	r.next.Trace(ctx, msg, r.redactFields(fields)...)
+}
+
+func (r *redactingLogger) Debug(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	r.next.Debug(ctx, msg, r.redactFields(fields)...)
+}
+
+func (r *redactingLogger) Info(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	r.next.Info(ctx, msg, r.redactFields(fields)...)
+}
+
+func (r *redactingLogger) Warn(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	r.next.Warn(ctx, msg, r.redactFields(fields)...)
+}
+
+func (r *redactingLogger) Error(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	r.next.Error(ctx, msg, r.redactFields(fields)...)
+}
+
+// redactFields copies the fields, the fields of the caller are not modified
+func (r *redactingLogger) redactFields(fields []map[string]interface{}) []map[string]interface{} {
+	redacted := make([]map[string]interface{}, len(fields))
+	for i, f := range fields {
+		redacted[i] = r.redactMap(f)
+	}
+	return redacted
+}
+
+// redactor masks the values of the redacted keys, it is shared by the
+// redacting logger and the cassette transport.
+type redactor struct {
+	keys map[string]bool
+}
+
+// newRedactor returns a redactor of the keys and the DefaultRedactedKeys
+func newRedactor(keys ...string) *redactor {
+	r := &redactor{keys: map[string]bool{}}
+	for _, k := range append(DefaultRedactedKeys, keys...) {
+		r.keys[strings.ToLower(k)] = true
+	}
+	return r
+}
+
+func (r *redactor) redactMap(m map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if r.keys[strings.ToLower(k)] {
+			redacted[k] = RedactedValue
+			continue
+		}
+		redacted[k] = r.redact(v)
+	}
+	return redacted
+}
+
+func (r *redactor) redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, e := range v {
+			redacted[i] = r.redact(e)
+		}
+		return redacted
+	case http.Header:
+		redacted := make(http.Header, len(v))
+		for k, values := range v {
+			if r.keys[strings.ToLower(k)] {
+				redacted[k] = []string{RedactedValue}
+				continue
+			}
+			redacted[k] = values
+		}
+		return redacted
+	case []byte:
+		return r.redactJSON(string(v))
+	case string:
+		return r.redactJSON(v)
+	default:
+		return v
+	}
+}
+
+// redactJSON redacts strings that contain a json object or array.  Other
+// strings are returned as is.
+func (r *redactor) redactJSON(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return s
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(trimmed), &v); err != nil {
+		return s
+	}
+
+	b, err := json.Marshal(r.redact(v))
+	if err != nil {
+		return s
+	}
+	return string(b)
+}
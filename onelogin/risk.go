@@ -0,0 +1,28 @@
+package onelogin
+
+// https://developers.onelogin.com/api-docs/2/vigilance/overview
+type RiskRule struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Target      string   `json:"target"`
+	Filters     []string `json:"filters"`
+	Source      string   `json:"source,omitempty"`
+	Action      string   `json:"action,omitempty"`
+}
+
+// RiskScores counts the risk scores of recent authentication events by
+// risk level
+type RiskScores struct {
+	Scores RiskScoreCounts `json:"scores"`
+	Total  int64           `json:"total"`
+}
+
+type RiskScoreCounts struct {
+	Minimal  int64 `json:"minimal"`
+	Low      int64 `json:"low"`
+	Medium   int64 `json:"medium"`
+	High     int64 `json:"high"`
+	VeryHigh int64 `json:"very_high"`
+}
@@ -0,0 +1,37 @@
+package onelogin
+
+// https://developers.onelogin.com/api-docs/2/self-registration-profiles/overview
+type SelfRegistrationProfile struct {
+	ID                    int64  `json:"id,omitempty"`
+	Name                  string `json:"name"`
+	URL                   string `json:"url"`
+	Enabled               bool   `json:"enabled"`
+	Title                 string `json:"title"`
+	Helptext              string `json:"helptext"`
+	ThankyouMessage       string `json:"thankyou_message"`
+	Moderated             bool   `json:"moderated"`
+	DefaultRoleID         *int64 `json:"default_role_id"`
+	DefaultGroupID        *int64 `json:"default_group_id"`
+	EmailVerificationType string `json:"email_verification_type,omitempty"`
+
+	// Domain lists are comma separated, the strategy selects which list is
+	// used
+	DomainWhitelist    string `json:"domain_whitelist"`
+	DomainBlacklist    string `json:"domain_blacklist"`
+	DomainListStrategy int64  `json:"domain_list_strategy"`
+
+	// Fields are read only, use the fields endpoints to add and remove them
+	Fields []SelfRegistrationProfileField `json:"fields,omitempty"`
+}
+
+const (
+	DomainListStrategyNone      int64 = 0
+	DomainListStrategyWhitelist int64 = 1
+	DomainListStrategyBlacklist int64 = 2
+)
+
+type SelfRegistrationProfileField struct {
+	ID                int64  `json:"id,omitempty"`
+	CustomAttributeID int64  `json:"custom_attribute_id"`
+	Name              string `json:"name,omitempty"`
+}
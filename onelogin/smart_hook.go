@@ -0,0 +1,45 @@
+package onelogin
+
+// https://developers.onelogin.com/api-docs/2/smart-hooks/overview
+type SmartHook struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Status   string `json:"status,omitempty"`
+	Disabled bool   `json:"disabled"`
+	Runtime  string `json:"runtime"`
+	Timeout  int64  `json:"timeout"`
+	Retries  int64  `json:"retries"`
+
+	// Function is the base64 encoded source of the hook
+	Function   string               `json:"function"`
+	Packages   map[string]string    `json:"packages"`
+	EnvVars    []string             `json:"env_vars"`
+	Options    *SmartHookOptions    `json:"options,omitempty"`
+	Conditions []SmartHookCondition `json:"conditions"`
+}
+
+type SmartHookOptions struct {
+	RiskEnabled          bool `json:"risk_enabled"`
+	LocationEnabled      bool `json:"location_enabled"`
+	MFADeviceInfoEnabled bool `json:"mfa_device_info_enabled"`
+}
+
+type SmartHookCondition struct {
+	Source   string `json:"source"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// SmartHookEnvVar values are write only, OneLogin never returns them
+type SmartHookEnvVar struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type SmartHookLog struct {
+	RequestID     string   `json:"request_id"`
+	CorrelationID string   `json:"correlation_id"`
+	CreatedAt     string   `json:"created_at"`
+	Events        []string `json:"events"`
+}
@@ -0,0 +1,60 @@
+package onelogin
+
+import (
+	"net/http"
+	"time"
+)
+
+// limitTransport limits the number of requests in flight at once.
+type limitTransport struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.sem }()
+
+	return roundTrip(t.next, req)
+}
+
+// traceTransport logs every http request and response at trace level.
+type traceTransport struct {
+	next http.RoundTripper
+	log  Logger
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.Redacted(),
+	}
+	t.log.Trace(req.Context(), "http request", fields)
+
+	start := time.Now()
+	resp, err := roundTrip(t.next, req)
+
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		t.log.Trace(req.Context(), "http request failed", fields)
+		return resp, err
+	}
+
+	fields["status_code"] = resp.StatusCode
+	t.log.Trace(req.Context(), "http response", fields)
+	return resp, nil
+}
+
+// roundTrip uses the default transport when next is nil.  The default
+// transport is looked up on every request so it can be replaced in tests.
+func roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(req)
+}
@@ -0,0 +1,17 @@
+package onelogin
+
+// https://developers.onelogin.com/api-docs/2/trusted-idps/overview
+type TrustedIDP struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+	Issuer      string `json:"issuer"`
+	SSOEndpoint string `json:"sso_endpoint"`
+
+	// Certificate is the PEM encoded signing certificate of the IdP
+	Certificate string `json:"certificate"`
+
+	LoginHint            bool   `json:"login_hint"`
+	UserAttributeMapping string `json:"user_attribute_mapping"`
+	JITProvisioning      bool   `json:"jit_provisioning"`
+}
@@ -4,4 +4,5 @@ package onelogin
 type User struct {
 	ID       int64  `json:"id,omitempty"`
 	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
 }
@@ -0,0 +1,63 @@
+package onelogin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Rate limit headers of OneLogin responses
+// https://developers.onelogin.com/api-docs/2/getting-started/rate-limits
+var rateLimitHeaders = map[string]string{
+	"X-RateLimit-Limit":     "rate_limit_limit",
+	"X-RateLimit-Remaining": "rate_limit_remaining",
+	"X-RateLimit-Reset":     "rate_limit_reset",
+}
+
+// newRequestID returns a random id that correlates the log records of a
+// request, including all of its retries.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// traceWire logs a single attempt of a request at trace level.  Bodies are
+// logged in full, the logger of the client redacts secrets from them.
+func (c *Client) traceWire(state *callState, httpReq *http.Request, resp *http.Response, respBody []byte, start time.Time, err error) {
+	fields := map[string]interface{}{
+		"request_id":  state.requestID,
+		"method":      httpReq.Method,
+		"path":        httpReq.URL.Path,
+		"query":       httpReq.URL.RawQuery,
+		"attempt":     state.attempt,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+
+	if httpReq.GetBody != nil {
+		if body, bodyErr := httpReq.GetBody(); bodyErr == nil {
+			b, _ := io.ReadAll(body)
+			fields["request_body"] = string(b)
+		}
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+
+	if resp != nil {
+		fields["status_code"] = resp.StatusCode
+		fields["response_body"] = string(respBody)
+		for header, key := range rateLimitHeaders {
+			if v := resp.Header.Get(header); v != "" {
+				fields[key] = v
+			}
+		}
+	}
+
+	c.log.Trace(httpReq.Context(), "http exchange", fields)
+}